@@ -6,8 +6,15 @@ Type enum, field interface, atomic types
 package anytype
 
 import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
 	"math"
+	"math/bits"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 /*
@@ -374,6 +381,7 @@ func (ego *atFloat) getVal() any {
 /*
 Defined in the field interface.
 Serializes the field into the JSON format, in this case prints a string representation of the value.
+Non-finite values have no JSON representation and are printed as +Inf, -Inf and NaN.
 Returns:
   - string representing serialized field.
 */
@@ -467,3 +475,379 @@ func (ego *atNil) isEqual(another any) bool {
 	_, ok := another.(*atNil)
 	return ok
 }
+
+/*
+Splits a tree form string into segments.
+Each segment starts with a dot (object key) or a hash (list index).
+
+Parameters:
+  - tf - tree form string.
+
+Returns:
+  - slice of segments.
+*/
+func splitTF(tf string) []string {
+	segments := []string{}
+	start := 0
+	for i := 1; i < len(tf); i++ {
+		if (tf[i] == '.' || tf[i] == '#') && i > start+1 {
+			segments = append(segments, tf[start:i])
+			start = i
+		}
+	}
+	return append(segments, tf[start:])
+}
+
+/*
+Converts a list segment of a tree form to an index.
+Causes a panic if the segment is not a valid integer.
+
+Parameters:
+  - segment - tree form segment without the leading hash.
+
+Returns:
+  - index.
+*/
+func parseIndex(segment string) int {
+	integer, err := strconv.ParseInt(segment, 0, bits.UintSize)
+	if err != nil {
+		panic(fmt.Sprintf("'%s' cannot be converted to int", segment))
+	}
+	return int(integer)
+}
+
+/*
+Gives a rank of a value type within the total ordering used by Compare.
+
+Parameters:
+  - val - value to rank.
+
+Returns:
+  - rank of the type.
+*/
+func typeRank(val any) int {
+	switch val.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case int, float64:
+		return 2
+	case string:
+		return 3
+	case List:
+		return 4
+	default:
+		return 5
+	}
+}
+
+/*
+Compares two values according to the total ordering (see Compare).
+The values have to be already converted to AnyType values.
+
+Parameters:
+  - a - first value,
+  - b - second value.
+
+Returns:
+  - negative number if a < b, zero if a == b, positive number if a > b.
+*/
+func compareValues(a any, b any) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		return ra - rb
+	}
+	switch x := a.(type) {
+	case bool:
+		y := b.(bool)
+		if x == y {
+			return 0
+		}
+		if !x {
+			return -1
+		}
+		return 1
+	case int:
+		if y, ok := b.(int); ok {
+			return compareInts(x, y)
+		}
+		if c := compareFloats(float64(x), b.(float64)); c != 0 {
+			return c
+		}
+		return -1
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareFloats(x, y)
+		}
+		if c := compareFloats(x, float64(b.(int))); c != 0 {
+			return c
+		}
+		return 1
+	case string:
+		return strings.Compare(x, b.(string))
+	case List:
+		y := b.(List)
+		for i := 0; i < x.Count() && i < y.Count(); i++ {
+			if c := compareValues(x.Get(i), y.Get(i)); c != 0 {
+				return c
+			}
+		}
+		return x.Count() - y.Count()
+	case Object:
+		y := b.(Object)
+		xKeys, yKeys := sortedKeysOf(x), sortedKeysOf(y)
+		for i := 0; i < len(xKeys) && i < len(yKeys); i++ {
+			if c := strings.Compare(xKeys[i], yKeys[i]); c != 0 {
+				return c
+			}
+		}
+		if len(xKeys) != len(yKeys) {
+			return len(xKeys) - len(yKeys)
+		}
+		for _, key := range xKeys {
+			if c := compareValues(x.Get(key), y.Get(key)); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+/*
+Compares two ints.
+
+Parameters:
+  - a - first int,
+  - b - second int.
+
+Returns:
+  - -1 if a < b, 0 if a == b, 1 if a > b.
+*/
+func compareInts(a int, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+/*
+Compares two floats. NaN is lower than any other number.
+
+Parameters:
+  - a - first float,
+  - b - second float.
+
+Returns:
+  - -1 if a < b, 0 if a == b, 1 if a > b.
+*/
+func compareFloats(a float64, b float64) int {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN || a < b:
+		return -1
+	case bNaN || a > b:
+		return 1
+	}
+	return 0
+}
+
+/*
+Compare compares two values according to the total ordering of AnyType values.
+Values of different types are ordered as follows: nil < bool < number < string < list < object.
+  - bools - false < true,
+  - numbers - ints and floats are compared by their numeric value, NaN is the lowest number, int is lower than a float with the same value,
+  - strings - lexicographically (byte-wise),
+  - lists - lexicographically element by element, a shorter list is lower than its extension,
+  - objects - first by their sorted keys (lexicographically), then by values of the keys in sorted order.
+
+Causes a panic if any of the values has an incompatible type.
+
+Parameters:
+  - a - first value,
+  - b - second value.
+
+Returns:
+  - negative number if a < b, zero if a == b, positive number if a > b.
+*/
+func Compare(a any, b any) int {
+	return compareValues(parseVal(a).getVal(), parseVal(b).getVal())
+}
+
+/*
+Marker of a missing value, which precedes all other values when sorting.
+*/
+type undefined struct{}
+
+/*
+Compares two values which can be missing (see undefined).
+
+Parameters:
+  - a - first value,
+  - b - second value.
+
+Returns:
+  - negative number if a < b, zero if a == b, positive number if a > b.
+*/
+func compareDefined(a any, b any) int {
+	_, aUndefined := a.(undefined)
+	_, bUndefined := b.(undefined)
+	switch {
+	case aUndefined && bUndefined:
+		return 0
+	case aUndefined:
+		return -1
+	case bUndefined:
+		return 1
+	}
+	return compareValues(a, b)
+}
+
+/*
+Computes a structural hash of a value, consistent with the isEqual method of fields.
+Equal values always have the same hash, nested objects and lists are hashed by content.
+
+Parameters:
+  - val - value to hash.
+
+Returns:
+  - computed hash.
+*/
+func hashValue(val any) uint64 {
+	h := fnv.New64a()
+	writeHash(h, val)
+	return h.Sum64()
+}
+
+/*
+Writes a structural representation of a value into a hash.
+
+Parameters:
+  - h - hash to write to,
+  - val - value to hash.
+*/
+func writeHash(h hash.Hash64, val any) {
+	buffer := make([]byte, 9)
+	switch v := val.(type) {
+	case nil:
+		h.Write([]byte{0})
+	case bool:
+		buffer[0] = 1
+		if v {
+			buffer[1] = 1
+		}
+		h.Write(buffer[:2])
+	case int:
+		buffer[0] = 2
+		binary.LittleEndian.PutUint64(buffer[1:], uint64(v))
+		h.Write(buffer)
+	case float64:
+		if v == 0 {
+			v = 0
+		}
+		buffer[0] = 3
+		binary.LittleEndian.PutUint64(buffer[1:], math.Float64bits(v))
+		h.Write(buffer)
+	case string:
+		buffer[0] = 4
+		binary.LittleEndian.PutUint64(buffer[1:], uint64(len(v)))
+		h.Write(buffer)
+		h.Write([]byte(v))
+	case List:
+		buffer[0] = 5
+		binary.LittleEndian.PutUint64(buffer[1:], uint64(v.Count()))
+		h.Write(buffer)
+		v.ForEachValue(func(x any) {
+			writeHash(h, x)
+		})
+	case Object:
+		var sum uint64
+		v.ForEach(func(key string, x any) {
+			sum += hashValue(NewList(key, x))
+		})
+		buffer[0] = 6
+		binary.LittleEndian.PutUint64(buffer[1:], sum)
+		h.Write(buffer)
+	}
+}
+
+/*
+Hash set of fields using structural equality.
+*/
+type fieldSet struct {
+	buckets map[uint64][]field
+}
+
+/*
+Creates a new empty set.
+
+Parameters:
+  - capacity - expected number of elements.
+
+Returns:
+  - pointer to the created set.
+*/
+func newFieldSet(capacity int) *fieldSet {
+	return &fieldSet{buckets: make(map[uint64][]field, capacity)}
+}
+
+/*
+Checks whether the set contains a field equal to the given one.
+
+Parameters:
+  - item - field to check.
+
+Returns:
+  - true if the set contains the field, false otherwise.
+*/
+func (ego *fieldSet) contains(item field) bool {
+	for _, candidate := range ego.buckets[hashValue(item.getVal())] {
+		if candidate.isEqual(item) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Adds a field to the set if no equal field is present.
+
+Parameters:
+  - item - field to add.
+
+Returns:
+  - true if the field has been added, false if it was already present.
+*/
+func (ego *fieldSet) add(item field) bool {
+	h := hashValue(item.getVal())
+	for _, candidate := range ego.buckets[h] {
+		if candidate.isEqual(item) {
+			return false
+		}
+	}
+	ego.buckets[h] = append(ego.buckets[h], item)
+	return true
+}
+
+/*
+Acquires the keys of an object in alphabetical order.
+
+Parameters:
+  - object - the object.
+
+Returns:
+  - sorted keys.
+*/
+func sortedKeysOf(object Object) []string {
+	keys := make([]string, 0, object.Count())
+	object.ForEach(func(key string, _ any) {
+		keys = append(keys, key)
+	})
+	sort.Strings(keys)
+	return keys
+}
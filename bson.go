@@ -0,0 +1,811 @@
+/*
+AnyType Library for Go
+BSON codec (bsonspec.org, version 1.1)
+*/
+
+package anytype
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+BSON element types.
+*/
+const (
+	bsonDouble    byte = 0x01
+	bsonString    byte = 0x02
+	bsonDocument  byte = 0x03
+	bsonArray     byte = 0x04
+	bsonBinary    byte = 0x05
+	bsonUndefined byte = 0x06
+	bsonObjectID  byte = 0x07
+	bsonBool      byte = 0x08
+	bsonDateTime  byte = 0x09
+	bsonNull      byte = 0x0a
+	bsonRegex     byte = 0x0b
+	bsonCode      byte = 0x0d
+	bsonSymbol    byte = 0x0e
+	bsonInt32     byte = 0x10
+	bsonTimestamp byte = 0x11
+	bsonInt64     byte = 0x12
+	bsonMaxKey    byte = 0x7f
+	bsonMinKey    byte = 0xff
+)
+
+/*
+Appends a little-endian unsigned integer to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - value - the integer,
+  - size - size of the integer in bytes (4 or 8).
+
+Returns:
+  - extended buffer.
+*/
+func appendUintLE(data []byte, value uint64, size int) []byte {
+	for i := 0; i < size; i++ {
+		data = append(data, byte(value>>(8*i)))
+	}
+	return data
+}
+
+/*
+Appends a null-terminated string (key of an element or part of a regular expression) to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - str - the string.
+
+Returns:
+  - extended buffer,
+  - error if the string contains a null byte or is not an UTF-8 encoding.
+*/
+func appendCString(data []byte, str string) ([]byte, error) {
+	if strings.IndexByte(str, 0) >= 0 {
+		return nil, fmt.Errorf("'%s' contains a null byte", strings.ReplaceAll(str, "\x00", "\\x00"))
+	}
+	if !utf8.ValidString(str) {
+		return nil, fmt.Errorf("not an UTF-8 encoding")
+	}
+	return append(append(data, str...), 0), nil
+}
+
+/*
+Appends a length-prefixed string to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - str - the string.
+
+Returns:
+  - extended buffer,
+  - error if the string is not an UTF-8 encoding.
+*/
+func appendBSONString(data []byte, str string) ([]byte, error) {
+	if !utf8.ValidString(str) {
+		return nil, fmt.Errorf("not an UTF-8 encoding")
+	}
+	return append(append(appendUintLE(data, uint64(len(str)+1), 4), str...), 0), nil
+}
+
+/*
+Appends an encoded document to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - keys - keys of the elements,
+  - values - values of the elements,
+  - depth - remaining nesting depth.
+
+Returns:
+  - extended buffer,
+  - error if any occurred.
+*/
+func appendBSONDocument(data []byte, keys []string, values []any, depth int) ([]byte, error) {
+	if depth <= 0 {
+		return nil, fmt.Errorf("maximum depth exceeded")
+	}
+	start := len(data)
+	data = append(data, 0, 0, 0, 0)
+	var err error
+	for i, key := range keys {
+		if data, err = appendBSONElement(data, key, values[i], depth-1); err != nil {
+			return nil, err
+		}
+	}
+	data = append(data, 0)
+	if len(data)-start > math.MaxInt32 {
+		return nil, fmt.Errorf("document too large")
+	}
+	binary.LittleEndian.PutUint32(data[start:], uint32(len(data)-start))
+	return data, nil
+}
+
+/*
+Appends an encoded element (type, key and value) to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - key - key of the element,
+  - value - value of the element,
+  - depth - remaining nesting depth.
+
+Returns:
+  - extended buffer,
+  - error if any occurred.
+*/
+func appendBSONElement(data []byte, key string, value any, depth int) ([]byte, error) {
+	kind := len(data)
+	data, err := appendCString(append(data, 0), key)
+	if err != nil {
+		return nil, err
+	}
+	switch typed := value.(type) {
+	case nil:
+		data[kind] = bsonNull
+	case bool:
+		data[kind] = bsonBool
+		if typed {
+			return append(data, 1), nil
+		}
+		return append(data, 0), nil
+	case int:
+		if typed >= math.MinInt32 && typed <= math.MaxInt32 {
+			data[kind] = bsonInt32
+			return appendUintLE(data, uint64(typed), 4), nil
+		}
+		data[kind] = bsonInt64
+		return appendUintLE(data, uint64(typed), 8), nil
+	case float64:
+		data[kind] = bsonDouble
+		return appendUintLE(data, math.Float64bits(typed), 8), nil
+	case string:
+		data[kind] = bsonString
+		return appendBSONString(data, typed)
+	case List:
+		data[kind] = bsonArray
+		keys := make([]string, typed.Count())
+		for i := range keys {
+			keys[i] = strconv.Itoa(i)
+		}
+		return appendBSONDocument(data, keys, typed.Slice(), depth)
+	case Object:
+		if special, ok := bsonSpecialKey(typed); ok {
+			return appendBSONSpecial(data, kind, special, typed.Get(special))
+		}
+		data[kind] = bsonDocument
+		keys := sortedKeysOf(typed)
+		values := make([]any, len(keys))
+		for i, key := range keys {
+			values[i] = typed.Get(key)
+		}
+		return appendBSONDocument(data, keys, values, depth)
+	}
+	return data, nil
+}
+
+/*
+Recognizes an object representing a BSON-only type.
+
+Parameters:
+  - object - object to check.
+
+Returns:
+  - the only key of the object,
+  - true if the key denotes a BSON-only type, false otherwise.
+*/
+func bsonSpecialKey(object Object) (string, bool) {
+	if object.Count() != 1 {
+		return "", false
+	}
+	key := object.Keys().GetString(0)
+	switch key {
+	case "$oid", "$date", "$binary", "$timestamp", "$regularExpression", "$code", "$minKey", "$maxKey":
+		return key, true
+	}
+	return "", false
+}
+
+/*
+Checks whether a value is an object with exactly the given keys holding values of the given types.
+
+Parameters:
+  - value - value to check,
+  - fields - types of the values by keys.
+
+Returns:
+  - true if the value matches, false otherwise.
+*/
+func bsonShape(value any, fields map[string]Type) bool {
+	object, ok := value.(Object)
+	if !ok || object.Count() != len(fields) {
+		return false
+	}
+	for key, kind := range fields {
+		if object.TypeOf(key) != kind {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Appends a value of a BSON-only type represented by a single-key object.
+
+Parameters:
+  - data - the buffer,
+  - kind - position of the element type in the buffer,
+  - key - key of the object,
+  - value - value of the key.
+
+Returns:
+  - extended buffer,
+  - error if the value does not match the type.
+*/
+func appendBSONSpecial(data []byte, kind int, key string, value any) ([]byte, error) {
+	invalid := fmt.Errorf("invalid value of '%s'", key)
+	switch key {
+	case "$oid":
+		str, _ := value.(string)
+		id, err := hex.DecodeString(str)
+		if err != nil || len(id) != 12 {
+			return nil, invalid
+		}
+		data[kind] = bsonObjectID
+		return append(data, id...), nil
+	case "$date":
+		millis, ok := value.(int)
+		if !ok {
+			return nil, invalid
+		}
+		data[kind] = bsonDateTime
+		return appendUintLE(data, uint64(millis), 8), nil
+	case "$binary":
+		if !bsonShape(value, map[string]Type{"base64": TypeString, "subType": TypeString}) {
+			return nil, invalid
+		}
+		payload, err := base64.StdEncoding.DecodeString(value.(Object).GetString("base64"))
+		subtype, err2 := strconv.ParseUint(value.(Object).GetString("subType"), 16, 8)
+		if err != nil || err2 != nil || len(payload) > math.MaxInt32 {
+			return nil, invalid
+		}
+		data[kind] = bsonBinary
+		return append(append(appendUintLE(data, uint64(len(payload)), 4), byte(subtype)), payload...), nil
+	case "$timestamp":
+		if !bsonShape(value, map[string]Type{"t": TypeInt, "i": TypeInt}) {
+			return nil, invalid
+		}
+		seconds, increment := value.(Object).GetInt("t"), value.(Object).GetInt("i")
+		if seconds < 0 || increment < 0 || uint64(seconds) > math.MaxUint32 || uint64(increment) > math.MaxUint32 {
+			return nil, invalid
+		}
+		data[kind] = bsonTimestamp
+		return appendUintLE(data, uint64(seconds)<<32|uint64(increment), 8), nil
+	case "$regularExpression":
+		if !bsonShape(value, map[string]Type{"pattern": TypeString, "options": TypeString}) {
+			return nil, invalid
+		}
+		data[kind] = bsonRegex
+		data, err := appendCString(data, value.(Object).GetString("pattern"))
+		if err == nil {
+			data, err = appendCString(data, value.(Object).GetString("options"))
+		}
+		return data, err
+	case "$code":
+		code, ok := value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		data[kind] = bsonCode
+		return appendBSONString(data, code)
+	}
+	if value != 1 {
+		return nil, invalid
+	}
+	if key == "$minKey" {
+		data[kind] = bsonMinKey
+	} else {
+		data[kind] = bsonMaxKey
+	}
+	return data, nil
+}
+
+/*
+BSONEncoder writes objects to a stream as BSON documents.
+*/
+type BSONEncoder struct {
+	writer   io.Writer
+	maxDepth int
+}
+
+/*
+NewBSONEncoder creates a new BSON encoder.
+
+Parameters:
+  - writer - destination of the encoded documents.
+
+Returns:
+  - pointer to the created encoder.
+*/
+func NewBSONEncoder(writer io.Writer) *BSONEncoder {
+	return &BSONEncoder{writer: writer, maxDepth: DefaultMaxDepth}
+}
+
+/*
+SetMaxDepth sets the maximum nesting depth of objects and lists.
+
+Parameters:
+  - depth - maximum depth.
+
+Returns:
+  - the encoder.
+*/
+func (ego *BSONEncoder) SetMaxDepth(depth int) *BSONEncoder {
+	ego.maxDepth = depth
+	return ego
+}
+
+/*
+Encode writes a single object to the stream as a BSON document.
+Integers are encoded as int32 (or int64 if they do not fit), floats as doubles, keys of objects are sorted.
+Objects with a single special key ($oid, $date, $binary, ...) are encoded as the BSON-only types they represent.
+
+Parameters:
+  - object - object to encode.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *BSONEncoder) Encode(object Object) error {
+	keys := sortedKeysOf(object)
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = object.Get(key)
+	}
+	data, err := appendBSONDocument(nil, keys, values, ego.maxDepth)
+	if err != nil {
+		return err
+	}
+	_, err = ego.writer.Write(data)
+	return err
+}
+
+/*
+BSONDecoder reads BSON documents from a stream.
+*/
+type BSONDecoder struct {
+	reader   *binaryReader
+	maxDepth int
+}
+
+/*
+NewBSONDecoder creates a new BSON decoder.
+The decoder may read data beyond the decoded documents if the reader does not implement io.ByteReader.
+
+Parameters:
+  - reader - source of the encoded documents.
+
+Returns:
+  - pointer to the created decoder.
+*/
+func NewBSONDecoder(reader io.Reader) *BSONDecoder {
+	return &BSONDecoder{reader: newBinaryReader(reader, "BSON"), maxDepth: DefaultMaxDepth}
+}
+
+/*
+SetMaxDepth sets the maximum nesting depth of documents and arrays.
+
+Parameters:
+  - depth - maximum depth.
+
+Returns:
+  - the decoder.
+*/
+func (ego *BSONDecoder) SetMaxDepth(depth int) *BSONDecoder {
+	ego.maxDepth = depth
+	return ego
+}
+
+/*
+Decode reads a single document from the stream.
+Int32 and int64 become integers, doubles become floats, arrays become lists and documents become objects.
+BSON-only types become objects with a single special key ($oid, $date, $binary, ...), undefined becomes nil and symbols become strings.
+
+Returns:
+  - decoded object,
+  - error if any occurred (io.EOF if there are no more documents).
+*/
+func (ego *BSONDecoder) Decode() (Object, error) {
+	start := ego.reader.offset
+	first, err := ego.reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	rest, err := ego.reader.readBytes(3)
+	if err != nil {
+		return nil, err
+	}
+	length := binary.LittleEndian.Uint32(append([]byte{first}, rest...))
+	if length < 5 || length > math.MaxInt32 {
+		return nil, (&bsonParser{base: start}).errorf("invalid document length %d", int32(length))
+	}
+	body, err := ego.reader.readBytes(uint64(length) - 4)
+	if err != nil {
+		return nil, err
+	}
+	data := append(append([]byte{first}, rest...), body...)
+	parser := &bsonParser{data: data, end: len(data), base: start}
+	return parser.document(ego.maxDepth)
+}
+
+/*
+Parser of a single BSON document held in memory.
+*/
+type bsonParser struct {
+	data []byte
+	pos  int
+	end  int
+	base int64
+}
+
+/*
+Creates an error with the current offset.
+
+Parameters:
+  - format - format of the message,
+  - args... - arguments of the message.
+
+Returns:
+  - created error.
+*/
+func (ego *bsonParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("not a valid BSON - %s at offset %d", fmt.Sprintf(format, args...), ego.base+int64(ego.pos))
+}
+
+/*
+Takes a given number of bytes of the current document.
+
+Parameters:
+  - length - number of bytes.
+
+Returns:
+  - the bytes,
+  - error if the document is shorter.
+*/
+func (ego *bsonParser) take(length int) ([]byte, error) {
+	if length < 0 || length > ego.end-ego.pos {
+		return nil, ego.errorf("unexpected end of document")
+	}
+	ego.pos += length
+	return ego.data[ego.pos-length : ego.pos], nil
+}
+
+/*
+Takes a little-endian 32-bit integer.
+
+Returns:
+  - the integer,
+  - error if the document is shorter.
+*/
+func (ego *bsonParser) readInt32() (int32, error) {
+	data, err := ego.take(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.LittleEndian.Uint32(data)), nil
+}
+
+/*
+Takes a little-endian 64-bit unsigned integer.
+
+Returns:
+  - the integer,
+  - error if the document is shorter.
+*/
+func (ego *bsonParser) readUint64() (uint64, error) {
+	data, err := ego.take(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(data), nil
+}
+
+/*
+Takes a null-terminated string.
+
+Returns:
+  - the string,
+  - error if the string is not terminated or is not an UTF-8 encoding.
+*/
+func (ego *bsonParser) readCString() (string, error) {
+	length := bytes.IndexByte(ego.data[ego.pos:ego.end], 0)
+	if length < 0 {
+		return "", ego.errorf("unterminated string")
+	}
+	if !utf8.Valid(ego.data[ego.pos : ego.pos+length]) {
+		return "", ego.errorf("not an UTF-8 encoding")
+	}
+	ego.pos += length + 1
+	return string(ego.data[ego.pos-length-1 : ego.pos-1]), nil
+}
+
+/*
+Takes a length-prefixed string.
+
+Returns:
+  - the string,
+  - error if the string is malformed.
+*/
+func (ego *bsonParser) readString() (string, error) {
+	length, err := ego.readInt32()
+	if err != nil {
+		return "", err
+	}
+	if length < 1 {
+		return "", ego.errorf("invalid string length %d", length)
+	}
+	data, err := ego.take(int(length))
+	if err != nil {
+		return "", err
+	}
+	if data[length-1] != 0 {
+		return "", ego.errorf("string not terminated by a null byte")
+	}
+	if !utf8.Valid(data[:length-1]) {
+		return "", ego.errorf("not an UTF-8 encoding")
+	}
+	return string(data[:length-1]), nil
+}
+
+/*
+Converts an integer to int, checking its range.
+
+Parameters:
+  - value - the integer.
+
+Returns:
+  - converted integer,
+  - error if the integer does not fit into int.
+*/
+func (ego *bsonParser) toInt(value int64) (int, error) {
+	if value < math.MinInt || value > math.MaxInt {
+		return 0, ego.errorf("integer out of range")
+	}
+	return int(value), nil
+}
+
+/*
+Takes an embedded document (or array) and converts it to an object.
+
+Parameters:
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded object,
+  - error if any occurred.
+*/
+func (ego *bsonParser) document(depth int) (Object, error) {
+	result := NewObject()
+	err := ego.elements(depth, func(key string, value any) {
+		result.Set(key, value)
+	})
+	return result, err
+}
+
+/*
+Takes an embedded document and calls a function for each of its elements.
+
+Parameters:
+  - depth - remaining nesting depth,
+  - store - function storing an element.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *bsonParser) elements(depth int, store func(key string, value any)) error {
+	if depth <= 0 {
+		return ego.errorf("maximum depth exceeded")
+	}
+	start, parent := ego.pos, ego.end
+	length, err := ego.readInt32()
+	if err != nil {
+		return err
+	}
+	if length < 5 || int(length) > parent-start {
+		ego.pos = start
+		return ego.errorf("invalid document length %d", length)
+	}
+	ego.end = start + int(length)
+	for {
+		kind, err := ego.take(1)
+		if err != nil {
+			return err
+		}
+		if kind[0] == 0 {
+			break
+		}
+		key, err := ego.readCString()
+		if err != nil {
+			return err
+		}
+		value, err := ego.value(kind[0], depth)
+		if err != nil {
+			return err
+		}
+		store(key, value)
+	}
+	if ego.pos != ego.end {
+		return ego.errorf("unexpected data after the end of document")
+	}
+	ego.end = parent
+	return nil
+}
+
+/*
+Takes a value of an element.
+
+Parameters:
+  - kind - type of the element,
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *bsonParser) value(kind byte, depth int) (any, error) {
+	switch kind {
+	case bsonDouble:
+		bits, err := ego.readUint64()
+		return math.Float64frombits(bits), err
+	case bsonString, bsonSymbol:
+		return ego.readString()
+	case bsonDocument:
+		return ego.document(depth - 1)
+	case bsonArray:
+		result := NewList()
+		err := ego.elements(depth-1, func(key string, value any) {
+			result.Add(value)
+		})
+		return result, err
+	case bsonBinary:
+		length, err := ego.readInt32()
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, ego.errorf("invalid binary length %d", length)
+		}
+		data, err := ego.take(int(length) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return NewObject("$binary", NewObject(
+			"base64", base64.StdEncoding.EncodeToString(data[1:]),
+			"subType", fmt.Sprintf("%02x", data[0]),
+		)), nil
+	case bsonUndefined, bsonNull:
+		return nil, nil
+	case bsonObjectID:
+		data, err := ego.take(12)
+		if err != nil {
+			return nil, err
+		}
+		return NewObject("$oid", hex.EncodeToString(data)), nil
+	case bsonBool:
+		data, err := ego.take(1)
+		if err != nil {
+			return nil, err
+		}
+		if data[0] > 1 {
+			return nil, ego.errorf("invalid boolean value %d", data[0])
+		}
+		return data[0] == 1, nil
+	case bsonDateTime:
+		bits, err := ego.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		millis, err := ego.toInt(int64(bits))
+		if err != nil {
+			return nil, err
+		}
+		return NewObject("$date", millis), nil
+	case bsonRegex:
+		pattern, err := ego.readCString()
+		if err != nil {
+			return nil, err
+		}
+		options, err := ego.readCString()
+		if err != nil {
+			return nil, err
+		}
+		return NewObject("$regularExpression", NewObject("pattern", pattern, "options", options)), nil
+	case bsonCode:
+		code, err := ego.readString()
+		if err != nil {
+			return nil, err
+		}
+		return NewObject("$code", code), nil
+	case bsonInt32:
+		value, err := ego.readInt32()
+		return int(value), err
+	case bsonTimestamp:
+		bits, err := ego.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		seconds, err := ego.toInt(int64(bits >> 32))
+		if err != nil {
+			return nil, err
+		}
+		increment, err := ego.toInt(int64(bits & math.MaxUint32))
+		if err != nil {
+			return nil, err
+		}
+		return NewObject("$timestamp", NewObject("t", seconds, "i", increment)), nil
+	case bsonInt64:
+		bits, err := ego.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		return ego.toInt(int64(bits))
+	case bsonMinKey:
+		return NewObject("$minKey", 1), nil
+	case bsonMaxKey:
+		return NewObject("$maxKey", 1), nil
+	}
+	return nil, ego.errorf("unsupported element type 0x%02x", kind)
+}
+
+/*
+MarshalBSON encodes an object as a BSON document.
+Integers are encoded as int32 (or int64 if they do not fit), floats as doubles, keys of objects are sorted.
+Objects with a single special key ($oid, $date, $binary, ...) are encoded as the BSON-only types they represent.
+
+Parameters:
+  - object - object to encode.
+
+Returns:
+  - encoded data,
+  - error if any occurred (a key containing a null byte, an invalid special object or exceeding of DefaultMaxDepth).
+*/
+func MarshalBSON(object Object) ([]byte, error) {
+	var result bytes.Buffer
+	err := NewBSONEncoder(&result).Encode(object)
+	return result.Bytes(), err
+}
+
+/*
+UnmarshalBSON decodes a BSON document.
+Int32 and int64 become integers, doubles become floats, arrays become lists and documents become objects.
+BSON-only types become objects with a single special key ($oid, $date, $binary, ...), undefined becomes nil and symbols become strings.
+
+Parameters:
+  - data - data to decode (exactly one document).
+
+Returns:
+  - decoded object,
+  - error if any occurred.
+*/
+func UnmarshalBSON(data []byte) (Object, error) {
+	decoder := NewBSONDecoder(bytes.NewReader(data))
+	object, err := decoder.Decode()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if decoder.reader.offset < int64(len(data)) {
+		return nil, decoder.reader.errorf("unexpected data after the document")
+	}
+	return object, nil
+}
@@ -0,0 +1,191 @@
+package anytype_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestBSON(t *testing.T) {
+
+	t.Run("encode", func(t *testing.T) {
+		for expected, o := range map[string]anytype.Object{
+			"05000000 00": Object(),
+			"16000000 02 68656c6c6f00 06000000 776f726c6400 00":                                                                  Object("hello", "world"),
+			"31000000 04 42534f4e00 26000000 02 3000 08000000 617765736f6d6500 01 3100 333333333333 1440 10 3200 c2070000 00 00": Object("BSON", List("awesome", 5.05, 1986)),
+			"10000000 08 6600 00 0a 6e00 08 7400 01 00":                                                                          Object("t", true, "f", false, "n", nil),
+			"14000000 03 6100 0c000000 10 6200 ffffffff 00 00":                                                                   Object("a", Object("b", -1)),
+		} {
+			data, err := anytype.MarshalBSON(o)
+			if err != nil || !bytes.Equal(data, decodeHex(t, expected)) {
+				t.Errorf("encoding of %s does not work properly: %x", o, data)
+			}
+		}
+		if strconv.IntSize == 64 {
+			large := int64(1) << 40
+			data, err := anytype.MarshalBSON(Object("i", int(large)))
+			if err != nil || !bytes.Equal(data, decodeHex(t, "10000000 12 6900 0000000000010000 00")) {
+				t.Errorf("encoding of 64-bit integers does not work properly: %x", data)
+			}
+		}
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		o, err := anytype.UnmarshalBSON(decodeHex(t, "31000000 04 42534f4e00 26000000 02 3000 08000000 617765736f6d6500 01 3100 333333333333 1440 10 3200 c2070000 00 00"))
+		if err != nil || !o.Equals(Object("BSON", List("awesome", 5.05, 1986))) {
+			t.Error("decoding of documents does not work properly")
+		}
+		o, err = anytype.UnmarshalBSON(decodeHex(t, "1c000000 12 6100 ffffffffffffffff 0e 6200 02000000 7800 06 6300 00"))
+		if err != nil || !o.Equals(Object("a", -1, "b", "x", "c", nil)) || o.TypeOf("a") != anytype.TypeInt {
+			t.Error("decoding of int64, symbols and undefined does not work properly")
+		}
+	})
+
+	t.Run("specialTypes", func(t *testing.T) {
+		for expected, o := range map[string]anytype.Object{
+			"14000000 07 6900 507f1f77bcf86cd799439011 00": Object("i", Object("$oid", "507f1f77bcf86cd799439011")),
+			"10000000 09 6400 e803000000000000 00":         Object("d", Object("$date", 1000)),
+			"10000000 09 6400 ffffffffffffffff 00":         Object("d", Object("$date", -1)),
+			"10000000 05 6200 03000000 80 010203 00":       Object("b", Object("$binary", Object("base64", "AQID", "subType", "80"))),
+			"10000000 11 7400 02000000 01000000 00":        Object("t", Object("$timestamp", Object("t", 1, "i", 2))),
+			"0d000000 0b 7200 5e6100 6900 00":              Object("r", Object("$regularExpression", Object("pattern", "^a", "options", "i"))),
+			"12000000 0d 6300 06000000 782b2b3b7d00 00":    Object("c", Object("$code", "x++;}")),
+			"0b000000 7f 4d00 ff 6d00 00":                  Object("m", Object("$minKey", 1), "M", Object("$maxKey", 1)),
+		} {
+			data, err := anytype.MarshalBSON(o)
+			if err != nil || !bytes.Equal(data, decodeHex(t, expected)) {
+				t.Errorf("encoding of %s does not work properly: %x %v", o, data, err)
+			}
+			decoded, err := anytype.UnmarshalBSON(data)
+			if err != nil || !decoded.Equals(o) {
+				t.Errorf("decoding of %s does not work properly: %s %v", expected, decoded, err)
+			}
+		}
+		data, err := anytype.MarshalBSON(Object("a", Object("$oid", "x", "other", 1)))
+		if err != nil || !bytes.Equal(data, decodeHex(t, "24000000 03 6100 1c000000 02 246f696400 02000000 7800 10 6f7468657200 01000000 00 00")) {
+			t.Errorf("objects with more keys are not encoded as documents: %x", data)
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o := Object(
+			"int", 42,
+			"negative", math.MinInt,
+			"max", math.MaxInt,
+			"float", 2.0,
+			"inf", math.Inf(-1),
+			"string", strings.Repeat("long ", 20000),
+			"unicode", "příliš žluťoučký",
+			"list", List(nil, true, false, List(), Object()),
+			"object", Object("nested", Object("deep", List(1, 2.5, Object("$oid", "000000000000000000000001")))),
+		)
+		data, err := anytype.MarshalBSON(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := anytype.UnmarshalBSON(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(o) || parsed.TypeOf("float") != anytype.TypeFloat || parsed.TypeOf("int") != anytype.TypeInt {
+			t.Error("encoded object does not round-trip")
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		var buffer bytes.Buffer
+		encoder := anytype.NewBSONEncoder(&buffer)
+		for _, o := range []anytype.Object{Object("a", 1), Object(), Object("b", List("c"))} {
+			if err := encoder.Encode(o); err != nil {
+				t.Fatal(err)
+			}
+		}
+		decoder := anytype.NewBSONDecoder(&buffer)
+		objects := List()
+		for {
+			o, err := decoder.Decode()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			objects.Add(o)
+		}
+		if !objects.Equals(List(Object("a", 1), Object(), Object("b", List("c")))) {
+			t.Error("streaming does not work properly")
+		}
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		deep := Object()
+		for i := 0; i < 10; i++ {
+			deep = Object("a", deep)
+		}
+		var buffer bytes.Buffer
+		if err := anytype.NewBSONEncoder(&buffer).SetMaxDepth(10).Encode(deep); err == nil {
+			t.Error("maximum depth of encoder is not checked")
+		}
+		if err := anytype.NewBSONEncoder(&buffer).SetMaxDepth(11).Encode(deep); err != nil {
+			t.Error("maximum depth of encoder is checked incorrectly")
+		}
+		if _, err := anytype.NewBSONDecoder(bytes.NewReader(buffer.Bytes())).SetMaxDepth(10).Decode(); err == nil {
+			t.Error("maximum depth of decoder is not checked")
+		}
+		if _, err := anytype.NewBSONDecoder(bytes.NewReader(buffer.Bytes())).SetMaxDepth(11).Decode(); err != nil {
+			t.Error("maximum depth of decoder is checked incorrectly")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, encoded := range []string{
+			"",
+			"050000",
+			"04000000 00",
+			"06000000 00",
+			"05000000 01",
+			"0a000000 0a 6100 00 00",
+			"0c000000 02 6100 00000000 00",
+			"0d000000 02 6100 02000000 7878 00",
+			"0d000000 02 6100 02000000 ff00 00",
+			"0a000000 08 6100 02 00",
+			"0a000000 13 6100 00 00",
+			"0c000000 03 6100 06000000 00 00",
+			"0c000000 05 6100 ffffffff 00 00",
+			"08000000 0a 61 00",
+			"05000000 00 00",
+		} {
+			if _, err := anytype.UnmarshalBSON(decodeHex(t, encoded)); err == nil {
+				t.Errorf("decoder did not return expected error for %s", encoded)
+			}
+		}
+		if _, err := anytype.UnmarshalBSON(decodeHex(t, "10000000 02 6100")); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Error("truncated data are not reported properly")
+		}
+		if _, err := anytype.UnmarshalBSON(decodeHex(t, "08000000 19 6100 00")); err == nil || !strings.HasSuffix(err.Error(), "at offset 7") {
+			t.Errorf("offset of an error is not reported properly: %v", err)
+		}
+		for _, o := range []anytype.Object{
+			Object("a\x00b", 1),
+			Object("a", "\xff"),
+			Object("a", Object("$oid", "xyz")),
+			Object("a", Object("$date", "2024-01-01")),
+			Object("a", Object("$binary", Object("base64", "!", "subType", "00"))),
+			Object("a", Object("$binary", Object("base64", "AQID", "subType", "100"))),
+			Object("a", Object("$timestamp", Object("t", -1, "i", 0))),
+			Object("a", Object("$regularExpression", Object("pattern", "a\x00", "options", ""))),
+			Object("a", Object("$minKey", 2)),
+		} {
+			if _, err := anytype.MarshalBSON(o); err == nil {
+				t.Errorf("encoding of %s does not return expected error", o)
+			}
+		}
+	})
+
+}
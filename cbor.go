@@ -0,0 +1,511 @@
+/*
+AnyType Library for Go
+CBOR codec (RFC 8949)
+*/
+
+package anytype
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+/*
+CBOR major types.
+*/
+const (
+	cborUint byte = iota << 5
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+/*
+CBOREncoder writes values to a stream in CBOR.
+*/
+type CBOREncoder struct {
+	writer   io.Writer
+	maxDepth int
+}
+
+/*
+NewCBOREncoder creates a new CBOR encoder.
+
+Parameters:
+  - writer - destination of the encoded values.
+
+Returns:
+  - pointer to the created encoder.
+*/
+func NewCBOREncoder(writer io.Writer) *CBOREncoder {
+	return &CBOREncoder{writer: writer, maxDepth: DefaultMaxDepth}
+}
+
+/*
+SetMaxDepth sets the maximum nesting depth of objects and lists.
+
+Parameters:
+  - depth - maximum depth.
+
+Returns:
+  - the encoder.
+*/
+func (ego *CBOREncoder) SetMaxDepth(depth int) *CBOREncoder {
+	ego.maxDepth = depth
+	return ego
+}
+
+/*
+Encode writes a single value to the stream.
+Integers are encoded as integers, floats as floating-point numbers (single precision if lossless), keys of objects are sorted.
+
+Parameters:
+  - value - value to encode (object, list or any value storable in them).
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *CBOREncoder) Encode(value any) error {
+	data, err := appendCBOR(nil, parseVal(value).getVal(), ego.maxDepth)
+	if err != nil {
+		return err
+	}
+	_, err = ego.writer.Write(data)
+	return err
+}
+
+/*
+Appends a CBOR head (major type and argument) to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - major - major type,
+  - argument - argument of the head (length or value).
+
+Returns:
+  - extended buffer.
+*/
+func appendCBORHead(data []byte, major byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return append(data, major|byte(argument))
+	case argument <= math.MaxUint8:
+		return append(data, major|24, byte(argument))
+	case argument <= math.MaxUint16:
+		return appendUint(append(data, major|25), argument, 2)
+	case argument <= math.MaxUint32:
+		return appendUint(append(data, major|26), argument, 4)
+	}
+	return appendUint(append(data, major|27), argument, 8)
+}
+
+/*
+Appends an encoded value to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - value - value to encode,
+  - depth - remaining nesting depth.
+
+Returns:
+  - extended buffer,
+  - error if the maximum depth is exceeded.
+*/
+func appendCBOR(data []byte, value any, depth int) ([]byte, error) {
+	switch typed := value.(type) {
+	case nil:
+		return append(data, cborSimple|22), nil
+	case bool:
+		if typed {
+			return append(data, cborSimple|21), nil
+		}
+		return append(data, cborSimple|20), nil
+	case int:
+		if typed < 0 {
+			return appendCBORHead(data, cborNegative, uint64(-(typed + 1))), nil
+		}
+		return appendCBORHead(data, cborUint, uint64(typed)), nil
+	case float64:
+		if single := float32(typed); float64(single) == typed || math.IsNaN(typed) {
+			return appendUint(append(data, cborSimple|26), uint64(math.Float32bits(single)), 4), nil
+		}
+		return appendUint(append(data, cborSimple|27), uint64(math.Float64bits(typed)), 8), nil
+	case string:
+		return append(appendCBORHead(data, cborText, uint64(len(typed))), typed...), nil
+	}
+	if depth <= 0 {
+		return nil, fmt.Errorf("maximum depth exceeded")
+	}
+	var err error
+	switch typed := value.(type) {
+	case List:
+		data = appendCBORHead(data, cborArray, uint64(typed.Count()))
+		for i := 0; i < typed.Count() && err == nil; i++ {
+			data, err = appendCBOR(data, typed.Get(i), depth-1)
+		}
+	case Object:
+		data = appendCBORHead(data, cborMap, uint64(typed.Count()))
+		for _, key := range sortedKeysOf(typed) {
+			data = append(appendCBORHead(data, cborText, uint64(len(key))), key...)
+			if data, err = appendCBOR(data, typed.Get(key), depth-1); err != nil {
+				break
+			}
+		}
+	}
+	return data, err
+}
+
+/*
+CBORDecoder reads CBOR values from a stream.
+*/
+type CBORDecoder struct {
+	reader   *binaryReader
+	maxDepth int
+}
+
+/*
+NewCBORDecoder creates a new CBOR decoder.
+The decoder may read data beyond the decoded values if the reader does not implement io.ByteReader.
+
+Parameters:
+  - reader - source of the encoded values.
+
+Returns:
+  - pointer to the created decoder.
+*/
+func NewCBORDecoder(reader io.Reader) *CBORDecoder {
+	return &CBORDecoder{reader: newBinaryReader(reader, "CBOR"), maxDepth: DefaultMaxDepth}
+}
+
+/*
+SetMaxDepth sets the maximum nesting depth of arrays and maps.
+
+Parameters:
+  - depth - maximum depth.
+
+Returns:
+  - the decoder.
+*/
+func (ego *CBORDecoder) SetMaxDepth(depth int) *CBORDecoder {
+	ego.maxDepth = depth
+	return ego
+}
+
+/*
+Decode reads a single value from the stream.
+Maps become objects (keys have to be text strings), arrays become lists, byte strings become strings,
+half, single and double precision floats become float64. Tags are ignored (the tagged value is decoded), undefined becomes nil.
+
+Returns:
+  - decoded value,
+  - error if any occurred (io.EOF if there are no more values).
+*/
+func (ego *CBORDecoder) Decode() (any, error) {
+	head, err := ego.reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	return ego.decode(head, ego.maxDepth)
+}
+
+/*
+Reads the argument of a head.
+
+Parameters:
+  - info - additional information of the head.
+
+Returns:
+  - the argument,
+  - error if any occurred.
+*/
+func (ego *CBORDecoder) readArgument(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return ego.reader.readUint(1 << (info - 24))
+	}
+	return 0, ego.reader.errorf("invalid additional information %d", info)
+}
+
+/*
+Decodes a value with an already read head.
+
+Parameters:
+  - head - initial byte of the value,
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *CBORDecoder) decode(head byte, depth int) (any, error) {
+	major, info := head&0xe0, head&0x1f
+	if major == cborSimple {
+		return ego.decodeSimple(info)
+	}
+	if info == 31 {
+		return ego.decodeIndefinite(major, depth)
+	}
+	argument, err := ego.readArgument(info)
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborUint, cborNegative:
+		if argument > math.MaxInt {
+			return nil, ego.reader.errorf("integer out of range")
+		}
+		if major == cborNegative {
+			return -1 - int(argument), nil
+		}
+		return int(argument), nil
+	case cborBytes, cborText:
+		data, err := ego.reader.readBytes(argument)
+		return string(data), err
+	}
+	if depth <= 0 {
+		return nil, ego.reader.errorf("maximum depth exceeded")
+	}
+	if major == cborTag {
+		return ego.decodeNext(depth - 1)
+	}
+	if major == cborArray {
+		result := NewList()
+		for i := uint64(0); i < argument; i++ {
+			value, err := ego.decodeNext(depth - 1)
+			if err != nil {
+				return nil, err
+			}
+			result.Add(value)
+		}
+		return result, nil
+	}
+	result := NewObject()
+	for i := uint64(0); i < argument; i++ {
+		head, err := ego.reader.readByte()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if err := ego.decodeEntry(result, depth-1, head); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+/*
+Reads the head of the next value and decodes it.
+
+Parameters:
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *CBORDecoder) decodeNext(depth int) (any, error) {
+	head, err := ego.reader.readByte()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return ego.decode(head, depth)
+}
+
+/*
+Decodes a key-value pair of a map and stores it into an object.
+
+Parameters:
+  - result - the object,
+  - depth - remaining nesting depth,
+  - head - already read head of the key.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *CBORDecoder) decodeEntry(result Object, depth int, head byte) error {
+	if head&0xe0 != cborText {
+		return ego.reader.errorf("map key is not a text string")
+	}
+	key, err := ego.decode(head, depth)
+	if err != nil {
+		return err
+	}
+	value, err := ego.decodeNext(depth)
+	if err != nil {
+		return err
+	}
+	result.Set(key.(string), value)
+	return nil
+}
+
+/*
+Decodes a simple value or a float.
+
+Parameters:
+  - info - additional information of the head.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *CBORDecoder) decodeSimple(info byte) (any, error) {
+	switch info {
+	case 20, 21:
+		return info == 21, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		half, err := ego.reader.readUint(2)
+		return halfToFloat(uint16(half)), err
+	case 26:
+		single, err := ego.reader.readUint(4)
+		return float64(math.Float32frombits(uint32(single))), err
+	case 27:
+		double, err := ego.reader.readUint(8)
+		return math.Float64frombits(double), err
+	case 31:
+		return nil, ego.reader.errorf("unexpected break")
+	}
+	return nil, ego.reader.errorf("unsupported simple value %d", info)
+}
+
+/*
+Converts a half precision float to float64.
+
+Parameters:
+  - half - bits of the half precision float.
+
+Returns:
+  - converted float.
+*/
+func halfToFloat(half uint16) float64 {
+	exponent, mantissa := int(half>>10&0x1f), float64(half&0x3ff)
+	var result float64
+	switch exponent {
+	case 0:
+		result = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			result = math.Inf(1)
+		} else {
+			result = math.NaN()
+		}
+	default:
+		result = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if half&0x8000 != 0 {
+		return -result
+	}
+	return result
+}
+
+/*
+Decodes an indefinite-length string, array or map.
+
+Parameters:
+  - major - major type,
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *CBORDecoder) decodeIndefinite(major byte, depth int) (any, error) {
+	switch major {
+	case cborBytes, cborText:
+		var result bytes.Buffer
+		for {
+			head, err := ego.reader.readByte()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if head == 0xff {
+				return result.String(), nil
+			}
+			if head&0xe0 != major || head&0x1f == 31 {
+				return nil, ego.reader.errorf("invalid chunk of an indefinite-length string")
+			}
+			chunk, err := ego.decode(head, depth)
+			if err != nil {
+				return nil, err
+			}
+			result.WriteString(chunk.(string))
+		}
+	case cborArray, cborMap:
+		if depth <= 0 {
+			return nil, ego.reader.errorf("maximum depth exceeded")
+		}
+		list, object := NewList(), NewObject()
+		for {
+			head, err := ego.reader.readByte()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if head == 0xff {
+				if major == cborArray {
+					return list, nil
+				}
+				return object, nil
+			}
+			if major == cborMap {
+				err = ego.decodeEntry(object, depth-1, head)
+			} else {
+				var value any
+				value, err = ego.decode(head, depth-1)
+				list.Add(value)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, ego.reader.errorf("invalid indefinite length")
+}
+
+/*
+MarshalCBOR encodes a value in CBOR (RFC 8949).
+Integers are encoded as integers, floats as floating-point numbers (single precision if lossless), keys of objects are sorted.
+
+Parameters:
+  - value - value to encode (object, list or any value storable in them).
+
+Returns:
+  - encoded data,
+  - error if the maximum depth (DefaultMaxDepth) is exceeded.
+*/
+func MarshalCBOR(value any) ([]byte, error) {
+	var result bytes.Buffer
+	err := NewCBOREncoder(&result).Encode(value)
+	return result.Bytes(), err
+}
+
+/*
+UnmarshalCBOR decodes a CBOR value (RFC 8949).
+Maps become objects (keys have to be text strings), arrays become lists, byte strings become strings,
+half, single and double precision floats become float64. Tags are ignored (the tagged value is decoded), undefined becomes nil.
+
+Parameters:
+  - data - data to decode (exactly one value).
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func UnmarshalCBOR(data []byte) (any, error) {
+	decoder := NewCBORDecoder(bytes.NewReader(data))
+	value, err := decoder.Decode()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if decoder.reader.offset < int64(len(data)) {
+		return nil, decoder.reader.errorf("unexpected data after the value")
+	}
+	return value, nil
+}
@@ -0,0 +1,223 @@
+package anytype_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func decodeHex(t *testing.T, str string) []byte {
+	data, err := hex.DecodeString(strings.ReplaceAll(str, " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCBOR(t *testing.T) {
+
+	t.Run("encode", func(t *testing.T) {
+		for value, expected := range map[any]string{
+			0:                       "00",
+			23:                      "17",
+			24:                      "18 18",
+			1000:                    "19 03e8",
+			1000000:                 "1a 000f4240",
+			-1:                      "20",
+			-1000:                   "39 03e7",
+			1.5:                     "fa 3fc00000",
+			1.1:                     "fb 3ff199999999999a",
+			1.0:                     "fa 3f800000",
+			false:                   "f4",
+			true:                    "f5",
+			"":                      "60",
+			"IETF":                  "64 49455446",
+			"ü":                     "62 c3bc",
+			strings.Repeat("a", 24): "78 18" + strings.Repeat("61", 24),
+		} {
+			data, err := anytype.MarshalCBOR(value)
+			if err != nil || !bytes.Equal(data, decodeHex(t, expected)) {
+				t.Errorf("encoding of %v does not work properly: %x", value, data)
+			}
+		}
+		if strconv.IntSize == 64 {
+			large := int64(1000000000000)
+			data, err := anytype.MarshalCBOR(int(large))
+			if err != nil || !bytes.Equal(data, decodeHex(t, "1b 000000e8d4a51000")) {
+				t.Errorf("encoding of 64-bit integers does not work properly: %x", data)
+			}
+			if value, err := anytype.UnmarshalCBOR(data); err != nil || value != int(large) {
+				t.Error("decoding of 64-bit integers does not work properly")
+			}
+		}
+		data, err := anytype.MarshalCBOR(Object("b", List(2, 3), "a", 1, "n", nil))
+		if err != nil || !bytes.Equal(data, decodeHex(t, "a3 6161 01 6162 82 02 03 616e f6")) {
+			t.Errorf("encoding of collections does not work properly: %x", data)
+		}
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		for encoded, expected := range map[string]any{
+			"00":                  0,
+			"18 64":               100,
+			"38 63":               -100,
+			"f9 0000":             0.0,
+			"f9 3c00":             1.0,
+			"f9 7bff":             65504.0,
+			"f9 0001":             5.960464477539063e-8,
+			"f9 c400":             -4.0,
+			"f9 fc00":             math.Inf(-1),
+			"fa 47c35000":         100000.0,
+			"fb 3ff199999999999a": 1.1,
+			"f6":                  nil,
+			"f7":                  nil,
+			"c0 74 323031332d30332d32315432303a30343a30305a": "2013-03-21T20:04:00Z",
+			"c1 1a 514b67b0":                1363896240,
+			"44 01020304":                   "\x01\x02\x03\x04",
+			"7f 657374726561 646d696e67 ff": "streaming",
+			"5f 42 0102 43 030405 ff":       "\x01\x02\x03\x04\x05",
+		} {
+			value, err := anytype.UnmarshalCBOR(decodeHex(t, encoded))
+			if err != nil || value != expected {
+				t.Errorf("decoding of %s does not work properly: %v %v", encoded, value, err)
+			}
+		}
+		value, err := anytype.UnmarshalCBOR(decodeHex(t, "f9 7e00"))
+		if err != nil || !math.IsNaN(value.(float64)) {
+			t.Error("decoding of NaN does not work properly")
+		}
+		value, err = anytype.UnmarshalCBOR(decodeHex(t, "9f 01 82 02 03 9f 04 05 ff ff"))
+		if err != nil || !value.(anytype.List).Equals(List(1, List(2, 3), List(4, 5))) {
+			t.Error("decoding of indefinite-length arrays does not work properly")
+		}
+		value, err = anytype.UnmarshalCBOR(decodeHex(t, "bf 6161 01 6162 9f 02 03 ff ff"))
+		if err != nil || !value.(anytype.Object).Equals(Object("a", 1, "b", List(2, 3))) {
+			t.Error("decoding of indefinite-length maps does not work properly")
+		}
+		value, err = anytype.UnmarshalCBOR(decodeHex(t, "a2 6161 01 6162 a1 6163 80"))
+		if err != nil || !value.(anytype.Object).Equals(Object("a", 1, "b", Object("c", List()))) {
+			t.Error("decoding of maps does not work properly")
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o := Object(
+			"int", 42,
+			"negative", math.MinInt,
+			"max", math.MaxInt,
+			"float", 2.0,
+			"small", 1e-300,
+			"inf", math.Inf(1),
+			"string", strings.Repeat("long ", 20000),
+			"list", List(nil, true, false, List(), Object()),
+			"object", Object("nested", Object("deep", List(1, 2.5))),
+		)
+		data, err := anytype.MarshalCBOR(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := anytype.UnmarshalCBOR(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed := value.(anytype.Object)
+		if !parsed.Equals(o) || parsed.TypeOf("float") != anytype.TypeFloat || parsed.TypeOf("int") != anytype.TypeInt {
+			t.Error("encoded value does not round-trip")
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		var buffer bytes.Buffer
+		encoder := anytype.NewCBOREncoder(&buffer)
+		for _, value := range []any{Object("a", 1), List(2), "three", nil} {
+			if err := encoder.Encode(value); err != nil {
+				t.Fatal(err)
+			}
+		}
+		decoder := anytype.NewCBORDecoder(&buffer)
+		values := List()
+		for {
+			value, err := decoder.Decode()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			values.Add(value)
+		}
+		if !values.Equals(List(Object("a", 1), List(2), "three", nil)) {
+			t.Error("streaming does not work properly")
+		}
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		deep := List()
+		for i := 0; i < 10; i++ {
+			deep = List(deep)
+		}
+		var buffer bytes.Buffer
+		if err := anytype.NewCBOREncoder(&buffer).SetMaxDepth(10).Encode(deep); err == nil {
+			t.Error("maximum depth of encoder is not checked")
+		}
+		if err := anytype.NewCBOREncoder(&buffer).SetMaxDepth(11).Encode(deep); err != nil {
+			t.Error("maximum depth of encoder is checked incorrectly")
+		}
+		if _, err := anytype.NewCBORDecoder(bytes.NewReader(buffer.Bytes())).SetMaxDepth(10).Decode(); err == nil {
+			t.Error("maximum depth of decoder is not checked")
+		}
+		if _, err := anytype.NewCBORDecoder(bytes.NewReader(buffer.Bytes())).SetMaxDepth(11).Decode(); err != nil {
+			t.Error("maximum depth of decoder is checked incorrectly")
+		}
+		if _, err := anytype.MarshalCBOR(Object("a", Object("b", 1))); err != nil {
+			t.Error("default maximum depth is too low")
+		}
+		bomb := bytes.Repeat([]byte{0x81}, anytype.DefaultMaxDepth+1)
+		if _, err := anytype.UnmarshalCBOR(append(bomb, 0x00)); err == nil || !strings.Contains(err.Error(), "depth") {
+			t.Error("default maximum depth of decoder is not checked")
+		}
+		tags := bytes.Repeat([]byte{0xc6}, anytype.DefaultMaxDepth+1)
+		if _, err := anytype.UnmarshalCBOR(append(tags, 0x00)); err == nil {
+			t.Error("maximum depth of nested tags is not checked")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, encoded := range []string{
+			"",
+			"18",
+			"62 61",
+			"82 01",
+			"a1 01 02",
+			"a1 6161",
+			"1c",
+			"1b ffffffffffffffff",
+			"f0",
+			"ff",
+			"7f 01 ff",
+			"7f 7f ff ff",
+			"9f 01",
+			"bf 01 02 ff",
+			"01 02",
+			"5b ffffffffffffffff 00",
+		} {
+			if _, err := anytype.UnmarshalCBOR(decodeHex(t, encoded)); err == nil {
+				t.Errorf("decoder did not return expected error for %s", encoded)
+			}
+		}
+		if _, err := anytype.UnmarshalCBOR(decodeHex(t, "82 01")); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Error("truncated data are not reported properly")
+		}
+		if _, err := anytype.UnmarshalCBOR(decodeHex(t, "a1 01 02")); err == nil || !strings.HasSuffix(err.Error(), "at offset 2") {
+			t.Error("offset of an error is not reported properly")
+		}
+	})
+
+}
@@ -0,0 +1,167 @@
+/*
+AnyType Library for Go
+Common parts of binary codecs
+*/
+
+package anytype
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+/*
+DefaultMaxDepth is the default maximum nesting depth of objects and lists accepted by binary encoders and decoders.
+*/
+const DefaultMaxDepth = 1000
+
+/*
+Reader of binary input, keeping track of the offset.
+*/
+type binaryReader struct {
+	reader interface {
+		io.Reader
+		io.ByteReader
+	}
+	offset int64
+	format string
+}
+
+/*
+Creates a new binary reader.
+
+Parameters:
+  - reader - source of the data,
+  - format - name of the format (for error messages).
+
+Returns:
+  - pointer to the created reader.
+*/
+func newBinaryReader(reader io.Reader, format string) *binaryReader {
+	ego := &binaryReader{format: format}
+	if buffered, ok := reader.(interface {
+		io.Reader
+		io.ByteReader
+	}); ok {
+		ego.reader = buffered
+	} else {
+		ego.reader = bufio.NewReader(reader)
+	}
+	return ego
+}
+
+/*
+Creates an error with the current offset.
+
+Parameters:
+  - format - format of the message,
+  - args... - arguments of the message.
+
+Returns:
+  - created error.
+*/
+func (ego *binaryReader) errorf(format string, args ...any) error {
+	return fmt.Errorf("not a valid %s - %s at offset %d", ego.format, fmt.Sprintf(format, args...), ego.offset)
+}
+
+/*
+Converts an end of input in the middle of a value to io.ErrUnexpectedEOF.
+
+Parameters:
+  - err - error to convert.
+
+Returns:
+  - converted error.
+*/
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+/*
+Reads a single byte.
+
+Returns:
+  - the byte,
+  - error if any occurred (io.EOF at the end of the input).
+*/
+func (ego *binaryReader) readByte() (byte, error) {
+	char, err := ego.reader.ReadByte()
+	if err == nil {
+		ego.offset++
+	}
+	return char, err
+}
+
+/*
+Reads a given number of bytes. Memory is allocated as the data arrive, so a forged length cannot exhaust it.
+
+Parameters:
+  - length - number of bytes to read.
+
+Returns:
+  - the bytes,
+  - error if any occurred.
+*/
+func (ego *binaryReader) readBytes(length uint64) ([]byte, error) {
+	if length > 1<<20 {
+		data, err := io.ReadAll(io.LimitReader(ego.reader, int64(length)))
+		ego.offset += int64(len(data))
+		if err == nil && uint64(len(data)) < length {
+			err = io.ErrUnexpectedEOF
+		}
+		return data, err
+	}
+	data := make([]byte, length)
+	n, err := io.ReadFull(ego.reader, data)
+	ego.offset += int64(n)
+	return data, unexpectedEOF(err)
+}
+
+/*
+Reads a big-endian unsigned integer.
+
+Parameters:
+  - size - size of the integer in bytes (1, 2, 4 or 8).
+
+Returns:
+  - the integer,
+  - error if any occurred.
+*/
+func (ego *binaryReader) readUint(size int) (uint64, error) {
+	data, err := ego.readBytes(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(data[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(data)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(data)), nil
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+/*
+Appends a big-endian unsigned integer to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - value - the integer,
+  - size - size of the integer in bytes (1, 2, 4 or 8).
+
+Returns:
+  - extended buffer.
+*/
+func appendUint(data []byte, value uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		data = append(data, byte(value>>(8*i)))
+	}
+	return data
+}
@@ -0,0 +1,279 @@
+/*
+AnyType Library for Go
+CSV parser and writer
+*/
+
+package anytype
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Regular expressions of numbers recognized by the type inference.
+Numbers with leading zeros are kept as strings, so codes like "007" are not damaged.
+*/
+var (
+	csvInt   = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)$`)
+	csvFloat = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+)
+
+/*
+CSVOptions configures reading and writing of CSV data.
+
+Fields:
+  - Comma - field delimiter, 0 means ',' (use '\t' for TSV),
+  - Columns - names of the columns; ParseCSV uses them instead of the header row,
+    ToCSV writes only these columns in the given order (nil means the union of all keys in alphabetical order),
+  - NoHeader - the data have no header row (ParseCSV then requires Columns),
+  - InferTypes - ParseCSV converts integers, floats, booleans and null to their types,
+    empty cells become nil (otherwise all values are strings),
+  - Unflatten - ParseCSV considers the column names tree forms without the leading dot (e.g. "address.city", "tags#0")
+    and rebuilds nested objects and lists, empty cells are omitted. Lists are created only for indexes forming
+    a sequence 0 to n-1 in the header, other indexes become object keys.
+*/
+type CSVOptions struct {
+	Comma      rune
+	Columns    []string
+	NoHeader   bool
+	InferTypes bool
+	Unflatten  bool
+}
+
+/*
+Converts a cell of a CSV record to a value.
+
+Parameters:
+  - cell - text of the cell,
+  - infer - whether the type should be inferred.
+
+Returns:
+  - the value.
+*/
+func parseCSVValue(cell string, infer bool) any {
+	if !infer {
+		return cell
+	}
+	switch {
+	case cell == "" || strings.EqualFold(cell, "null"):
+		return nil
+	case strings.EqualFold(cell, "true"):
+		return true
+	case strings.EqualFold(cell, "false"):
+		return false
+	case csvInt.MatchString(cell):
+		if value, err := strconv.ParseInt(cell, 10, strconv.IntSize); err == nil {
+			return int(value)
+		}
+	case csvFloat.MatchString(cell):
+		if value, err := strconv.ParseFloat(cell, 64); err == nil {
+			return value
+		}
+	}
+	return cell
+}
+
+/*
+Creates an object from a CSV record.
+Invalid tree forms in the column names cause a panic.
+
+Parameters:
+  - columns - names of the columns (tree forms if the record is unflattened),
+  - record - cells of the record,
+  - options - CSV options.
+
+Returns:
+  - created object.
+*/
+func parseCSVRecord(columns []string, record []string, options CSVOptions) Object {
+	result := NewObject()
+	for i, column := range columns {
+		value := parseCSVValue(record[i], options.InferTypes)
+		if !options.Unflatten {
+			result.Set(column, value)
+		} else if record[i] != "" {
+			result.SetTF(column, value)
+		}
+	}
+	return result
+}
+
+/*
+ParseCSV creates a new list of objects from CSV data, each record becomes an object keyed by the column names.
+Without type inference, all values are strings.
+
+Parameters:
+  - reader - source of the data,
+  - options - CSV options.
+
+Returns:
+  - created list,
+  - error if any occurred (with the line).
+*/
+func ParseCSV(reader io.Reader, options CSVOptions) (List, error) {
+	csvReader := csv.NewReader(reader)
+	if options.Comma != 0 {
+		csvReader.Comma = options.Comma
+	}
+	columns := options.Columns
+	if columns != nil {
+		csvReader.FieldsPerRecord = len(columns)
+	} else if options.NoHeader {
+		return nil, errors.New("columns have to be specified for CSV without a header")
+	}
+	convertError := func(err error) error {
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			return fmt.Errorf("not a valid CSV - %s on line %d", parseError.Err, parseError.Line)
+		}
+		return err
+	}
+	result := NewList()
+	if !options.NoHeader {
+		header, err := csvReader.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, convertError(err)
+		}
+		if columns == nil {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+			columns = header
+		}
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if seen[column] {
+			return nil, fmt.Errorf("not a valid CSV - duplicate column '%s' on line 1", column)
+		}
+		seen[column] = true
+	}
+	keys := columns
+	if options.Unflatten {
+		tfs := make([]string, len(columns))
+		for i, column := range columns {
+			tfs[i] = "." + column
+		}
+		keys = denseIndexes(tfs)
+	}
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, convertError(err)
+		}
+		line, _ := csvReader.FieldPos(0)
+		object, err := func() (object Object, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("not a valid CSV - %v on line %d", r, line)
+				}
+			}()
+			return parseCSVRecord(keys, record, options), nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		result.Add(object)
+	}
+}
+
+/*
+Converts a value to a cell of a CSV record.
+
+Parameters:
+  - value - value to convert.
+
+Returns:
+  - text of the cell.
+*/
+func formatCSVValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		str := strconv.FormatFloat(typed, 'g', -1, 64)
+		if !math.IsNaN(typed) && !math.IsInf(typed, 0) && !strings.ContainsAny(str, ".e") {
+			str += ".0"
+		}
+		return str
+	case Object:
+		return typed.String()
+	case List:
+		return typed.String()
+	}
+	return fmt.Sprint(value)
+}
+
+/*
+ToCSV writes a list of objects as CSV data, one record per object.
+Nested objects and lists are flattened into columns named by tree forms without the leading dot
+(e.g. "address.city", "tags#0"), empty nested structures are written as JSON. Nil and missing values are written as empty cells.
+
+Parameters:
+  - list - list of objects to write,
+  - writer - destination of the data,
+  - options - CSV options.
+
+Returns:
+  - error if any occurred (including an element which is not an object).
+*/
+func ToCSV(list List, writer io.Writer, options CSVOptions) error {
+	rows := make([]Object, list.Count())
+	union := make(map[string]bool)
+	for i := range rows {
+		object, ok := list.Get(i).(Object)
+		if !ok {
+			return fmt.Errorf("element %d is not an object", i)
+		}
+		rows[i] = NewObject()
+		object.FlattenWith(FlattenOptions{}).ForEach(func(tf string, value any) {
+			rows[i].Set(tf[1:], value)
+			union[tf[1:]] = true
+		})
+	}
+	columns := options.Columns
+	if columns == nil {
+		columns = make([]string, 0, len(union))
+		for column := range union {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+	}
+	csvWriter := csv.NewWriter(writer)
+	if options.Comma != 0 {
+		csvWriter.Comma = options.Comma
+	}
+	if !options.NoHeader {
+		if err := csvWriter.Write(columns); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = ""
+			if row.KeyExists(column) {
+				record[i] = formatCSVValue(row.Get(column))
+			}
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
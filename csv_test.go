@@ -0,0 +1,148 @@
+package anytype_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestCSV(t *testing.T) {
+
+	t.Run("parse", func(t *testing.T) {
+		l, err := anytype.ParseCSV(strings.NewReader("\ufeffname,age\r\n\"Doe, John\",42\n\"say \"\"hi\"\"\",\n"), anytype.CSVOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !l.Equals(List(Object("name", "Doe, John", "age", "42"), Object("name", "say \"hi\"", "age", ""))) {
+			t.Error("parsing of CSV does not work properly")
+		}
+		l, err = anytype.ParseCSV(strings.NewReader("a\tb\n1\t2\n"), anytype.CSVOptions{Comma: '\t'})
+		if err != nil || !l.Equals(List(Object("a", "1", "b", "2"))) {
+			t.Error("parsing of TSV does not work properly")
+		}
+		l, err = anytype.ParseCSV(strings.NewReader("1,2\n3,4\n"), anytype.CSVOptions{Columns: []string{"x", "y"}, NoHeader: true})
+		if err != nil || !l.Equals(List(Object("x", "1", "y", "2"), Object("x", "3", "y", "4"))) {
+			t.Error("parsing of CSV without a header does not work properly")
+		}
+		l, err = anytype.ParseCSV(strings.NewReader("a,b\n1,2\n"), anytype.CSVOptions{Columns: []string{"x", "y"}})
+		if err != nil || !l.Equals(List(Object("x", "1", "y", "2"))) {
+			t.Error("renaming of columns does not work properly")
+		}
+		l, err = anytype.ParseCSV(strings.NewReader(""), anytype.CSVOptions{})
+		if err != nil || !l.Empty() {
+			t.Error("parsing of empty CSV does not work properly")
+		}
+	})
+
+	t.Run("inference", func(t *testing.T) {
+		l, err := anytype.ParseCSV(strings.NewReader(
+			"int,negative,float,exponent,bool,upper,null,empty,zip,big,text\n"+
+				"42,-7,2.50,1e3,true,FALSE,null,,007,123456789012345678901234567890,1.2.3\n",
+		), anytype.CSVOptions{InferTypes: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object(
+			"int", 42,
+			"negative", -7,
+			"float", 2.5,
+			"exponent", 1000.0,
+			"bool", true,
+			"upper", false,
+			"null", nil,
+			"empty", nil,
+			"zip", "007",
+			"big", "123456789012345678901234567890",
+			"text", "1.2.3",
+		)
+		if !l.Equals(List(expected)) {
+			t.Error("type inference does not work properly")
+		}
+	})
+
+	t.Run("unflatten", func(t *testing.T) {
+		l, err := anytype.ParseCSV(strings.NewReader(
+			"id,address.city,address.zip,tags#0,tags#1\n1,Prague,,a,b\n2,,,c,\n",
+		), anytype.CSVOptions{InferTypes: true, Unflatten: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := List(
+			Object("id", 1, "address", Object("city", "Prague"), "tags", List("a", "b")),
+			Object("id", 2, "tags", List("c")),
+		)
+		if !l.Equals(expected) {
+			t.Error("unflattening of columns does not work properly")
+		}
+		l, err = anytype.ParseCSV(strings.NewReader("id,tags#999999999\n1,a\n"), anytype.CSVOptions{InferTypes: true, Unflatten: true})
+		if err != nil || !l.Equals(List(Object("id", 1, "tags", Object("999999999", "a")))) {
+			t.Error("sparse list indexes in columns should become object keys")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		l := List(
+			Object("id", 1, "name", "Doe, John", "score", 2.0, "active", true, "address", Object("city", "Prague"), "tags", List("a", "b")),
+			Object("id", 2, "name", "line\nbreak", "score", 1.5, "active", false, "note", nil, "empty", List()),
+		)
+		var buffer bytes.Buffer
+		if err := anytype.ToCSV(l, &buffer, anytype.CSVOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != "active,address.city,empty,id,name,note,score,tags#0,tags#1\n"+
+			"true,Prague,,1,\"Doe, John\",,2.0,a,b\n"+
+			"false,,[],2,\"line\nbreak\",,1.5,,\n" {
+			t.Error("list is not exported properly")
+		}
+		parsed, err := anytype.ParseCSV(&buffer, anytype.CSVOptions{InferTypes: true, Unflatten: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l.GetObject(1).Unset("note").Set("empty", "[]")
+		if !parsed.Equals(l) || parsed.GetObject(0).TypeOf("score") != anytype.TypeFloat {
+			t.Error("exported CSV does not round-trip")
+		}
+		buffer.Reset()
+		options := anytype.CSVOptions{Comma: '\t', Columns: []string{"name", "address.city", "missing"}, NoHeader: true}
+		if err := anytype.ToCSV(l, &buffer, options); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != "Doe, John\tPrague\t\n\"line\nbreak\"\t\t\n" {
+			t.Error("chosen columns are not exported properly")
+		}
+		if err := anytype.ToCSV(List(Object(), 1), &buffer, anytype.CSVOptions{}); err == nil {
+			t.Error("exporting of a list which does not contain objects does not return expected error")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for csv, position := range map[string]string{
+			"a,b\n1,2,3":         "line 2",
+			"a,b\n1,2\n3":        "line 3",
+			"a,a\n1,2":           "line 1",
+			"a,\"b\nc":           "line 2",
+			"a\nx\"y":            "line 2",
+			"a,a#0\n1,2":         "line 2",
+			"a#x\n1":             "line 2",
+			"\n\nb.,c\n1,2\n3,4": "line 4",
+		} {
+			_, err := anytype.ParseCSV(strings.NewReader(csv), anytype.CSVOptions{Unflatten: true})
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", csv)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), position) {
+				t.Errorf("wrong position reported for %q: %s", csv, err)
+			}
+		}
+		if _, err := anytype.ParseCSV(strings.NewReader("1,2"), anytype.CSVOptions{NoHeader: true}); err == nil {
+			t.Error("parsing without a header and columns does not return expected error")
+		}
+		if _, err := anytype.ParseCSV(strings.NewReader("1,2"), anytype.CSVOptions{Columns: []string{"a"}, NoHeader: true}); err == nil {
+			t.Error("parser did not return expected error for a wrong number of columns")
+		}
+	})
+
+}
@@ -0,0 +1,141 @@
+/*
+AnyType Library for Go
+Double-ended queue implementation of the List
+*/
+
+package anytype
+
+/*
+Deque, a list with amortized O(1) adding and removing of elements at both ends.
+Keeps a spare capacity in front of the elements, so inserting at the beginning does not shift them.
+
+Implements:
+  - field,
+  - List.
+*/
+type deque struct {
+	*list
+	buf []field
+}
+
+/*
+NewDeque creates a new double-ended queue.
+Adding (Add, Unshift, Insert at index 0) and removing (Pop, Shift, Delete of the first or the last element)
+at both ends of the deque takes amortized constant time.
+
+Parameters:
+  - values... - any amount of initial elements.
+
+Returns:
+  - pointer to the created deque.
+*/
+func NewDeque(values ...any) List {
+	ego := &deque{list: &list{val: []field{}}}
+	ego.Init(ego)
+	ego.Add(values...)
+	return ego
+}
+
+/*
+Finds the spare capacity in front of the elements.
+
+Returns:
+  - number of free positions before the first element (-1 if the elements were moved out of the buffer).
+*/
+func (ego *deque) front() int {
+	offset := cap(ego.buf) - cap(ego.val)
+	if cap(ego.val) == 0 || offset < 0 || offset >= cap(ego.buf) {
+		return -1
+	}
+	if &ego.buf[:cap(ego.buf)][offset] != &ego.val[:cap(ego.val)][0] {
+		return -1
+	}
+	return offset
+}
+
+/*
+Inserts a field before the first element.
+
+Parameters:
+  - item - field to insert.
+*/
+func (ego *deque) pushFront(item field) {
+	offset := ego.front()
+	if offset <= 0 {
+		count := len(ego.val)
+		spare := count
+		if spare < 4 {
+			spare = 4
+		}
+		ego.buf = make([]field, spare+2*count)
+		copy(ego.buf[spare:], ego.val)
+		ego.val = ego.buf[spare : spare+count]
+		offset = spare
+	}
+	ego.val = ego.buf[offset-1 : offset+len(ego.val)]
+	ego.val[0] = item
+}
+
+/*
+Defined in the field interface.
+Creates a deep copy of the deque.
+
+Returns:
+  - copied deque.
+*/
+func (ego *deque) copy() any {
+	result := &deque{list: ego.list.copy().(*list)}
+	result.Init(result)
+	return result
+}
+
+func (ego *deque) Clone() List {
+	return ego.copy().(List)
+}
+
+func (ego *deque) Insert(index int, value any) List {
+	if index == 0 && ego.Count() > 0 {
+		ego.pushFront(parseVal(value))
+		return ego.Ego()
+	}
+	return ego.list.Insert(index, value)
+}
+
+func (ego *deque) Unshift(values ...any) List {
+	for i := len(values) - 1; i >= 0; i-- {
+		ego.pushFront(parseVal(values[i]))
+	}
+	return ego.Ego()
+}
+
+func (ego *deque) Delete(indexes ...int) List {
+	if len(indexes) == 1 && indexes[0] == 0 && ego.Count() > 0 {
+		ego.val[0] = nil
+		ego.val = ego.val[1:]
+		return ego.Ego()
+	}
+	count := len(ego.val)
+	ego.list.Delete(indexes...)
+	ego.release(len(ego.val), count)
+	return ego.Ego()
+}
+
+/*
+Clears the positions of the buffer behind the elements, so the removed values can be garbage collected.
+
+Parameters:
+  - start - first vacated position,
+  - end - position after the last vacated one.
+*/
+func (ego *deque) release(start int, end int) {
+	vacated := ego.val[start:end]
+	for i := range vacated {
+		vacated[i] = nil
+	}
+}
+
+func (ego *deque) Clear() List {
+	ego.release(0, len(ego.val))
+	ego.val = ego.val[:0]
+	return ego.Ego()
+}
@@ -0,0 +1,52 @@
+package anytype_test
+
+import (
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestDeque(t *testing.T) {
+
+	t.Run("ends", func(t *testing.T) {
+		d := anytype.NewDeque(3, 4)
+		d.Unshift(1, 2).Add(5).Insert(0, 0)
+		if !d.Equals(List(0, 1, 2, 3, 4, 5)) || !List(0, 1, 2, 3, 4, 5).Equals(d) {
+			t.Fatal("adding to both ends does not work properly")
+		}
+		d.Shift().Pop().Delete(0)
+		if !d.Equals(List(2, 3, 4)) {
+			t.Error("removing from both ends does not work properly")
+		}
+		for i := 0; i < 100; i++ {
+			d.Unshift(i).Add(i)
+		}
+		for i := 0; i < 99; i++ {
+			d.Shift().Pop()
+		}
+		if !d.Equals(List(0, 2, 3, 4, 0)) {
+			t.Error("repeated operations at both ends do not work properly")
+		}
+		d.Clear().Unshift(1).Add(2).Unshift(0)
+		if !d.Equals(List(0, 1, 2)) || d.Pop().Pop().Pop().Count() != 0 {
+			t.Error("deque cannot be reused after clearing")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		d := anytype.NewDeque(1, 2, 3)
+		d.Insert(1, 1.5).Delete(2, 3).Replace(0, 0)
+		if !d.Equals(List(0, 1.5)) || d.Count() != 2 {
+			t.Error("deque does not behave as a list")
+		}
+		c := d.Clone()
+		c.Unshift(-1)
+		if !d.Equals(List(0, 1.5)) || !c.Equals(List(-1, 0, 1.5)) {
+			t.Error("cloning of deque does not work properly")
+		}
+		if !Object("deque", d).Clone().GetList("deque").Unshift(-1).Equals(List(-1, 0, 1.5)) {
+			t.Error("deque is not copied with its parent")
+		}
+	})
+
+}
@@ -0,0 +1,227 @@
+/*
+AnyType Library for Go
+Flattening of nested structures into single-level objects
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+FlattenOptions configures flattening of nested structures into single-level objects and back.
+
+Fields:
+  - Separator - string separating keys and indexes in the paths, empty string means tree form paths (e.g. ".a.b#0"),
+  - Brackets - list indexes are written in brackets (e.g. "a.b[0]") instead of separate segments (e.g. "a.b.0"),
+    ignored for tree form paths,
+  - MaxDepth - maximal number of segments in a path, deeper structures are kept as values (0 means unlimited),
+    ignored by unflattening,
+  - NumericKeys - numeric segments are unflattened as object keys instead of list indexes (flattened lists are then
+    rebuilt as objects), ignored for tree form paths and with Brackets, where indexes are distinguished by the syntax.
+*/
+type FlattenOptions struct {
+	Separator   string
+	Brackets    bool
+	MaxDepth    int
+	NumericKeys bool
+}
+
+/*
+Creates a path of a field within an object.
+
+Parameters:
+  - path - path of the object,
+  - key - key of the field.
+
+Returns:
+  - path of the field.
+*/
+func (ego FlattenOptions) keyPath(path string, key string) string {
+	if ego.Separator == "" {
+		return path + "." + key
+	}
+	if path == "" {
+		return key
+	}
+	return path + ego.Separator + key
+}
+
+/*
+Creates a path of an element within a list.
+
+Parameters:
+  - path - path of the list,
+  - index - index of the element.
+
+Returns:
+  - path of the element.
+*/
+func (ego FlattenOptions) indexPath(path string, index int) string {
+	switch {
+	case ego.Separator == "":
+		return path + "#" + strconv.Itoa(index)
+	case ego.Brackets:
+		return path + "[" + strconv.Itoa(index) + "]"
+	default:
+		return ego.keyPath(path, strconv.Itoa(index))
+	}
+}
+
+/*
+Stores all leaves of a value into a flat object.
+
+Parameters:
+  - result - flat object,
+  - options - flattening options,
+  - path - path of the value,
+  - depth - number of segments of the path,
+  - value - value to flatten.
+*/
+func flattenValue(result Object, options FlattenOptions, path string, depth int, value any) {
+	deeper := options.MaxDepth <= 0 || depth < options.MaxDepth
+	switch v := value.(type) {
+	case Object:
+		if deeper && (depth == 0 || !v.Empty()) {
+			v.ForEach(func(key string, val any) {
+				flattenValue(result, options, options.keyPath(path, key), depth+1, val)
+			})
+			return
+		}
+	case List:
+		if deeper && (depth == 0 || !v.Empty()) {
+			v.ForEach(func(i int, val any) {
+				flattenValue(result, options, options.indexPath(path, i), depth+1, val)
+			})
+			return
+		}
+	}
+	result.Set(path, value)
+}
+
+/*
+Checks whether a string consists of decimal digits only.
+
+Parameters:
+  - str - string to check.
+
+Returns:
+  - true if the string is a non-negative integer, false otherwise.
+*/
+func isDigits(str string) bool {
+	if str == "" {
+		return false
+	}
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Converts a flat path to a tree form.
+Causes a panic if the path is not valid.
+
+Parameters:
+  - path - flat path,
+  - options - flattening options.
+
+Returns:
+  - tree form string.
+*/
+func (ego FlattenOptions) treeForm(path string) string {
+	if ego.Separator == "" {
+		return path
+	}
+	var tf strings.Builder
+	for _, segment := range strings.Split(path, ego.Separator) {
+		if !ego.Brackets {
+			if isDigits(segment) && !ego.NumericKeys {
+				tf.WriteString("#" + segment)
+			} else {
+				tf.WriteString("." + segment)
+			}
+			continue
+		}
+		key := segment
+		if open := strings.IndexByte(segment, '['); open >= 0 {
+			key = segment[:open]
+			indexes := segment[open:]
+			segment = key
+			for indexes != "" {
+				end := strings.IndexByte(indexes, ']')
+				if indexes[0] != '[' || end < 0 || !isDigits(indexes[1:end]) {
+					panic(fmt.Sprintf("'%s' is not a valid flat path", path))
+				}
+				segment += "#" + indexes[1:end]
+				indexes = indexes[end+1:]
+			}
+		}
+		if key != "" {
+			tf.WriteString(".")
+		}
+		tf.WriteString(segment)
+	}
+	return tf.String()
+}
+
+/*
+Converts list indexes of tree forms, which do not form a dense sequence 0 to n-1 within their list, to object keys.
+Protects the rebuilt structures from being padded by nils up to an arbitrary index. Invalid indexes are kept,
+so setting the tree form reports them.
+
+Parameters:
+  - tfs - tree forms of all values to set.
+
+Returns:
+  - converted tree forms in the same order.
+*/
+func denseIndexes(tfs []string) []string {
+	indexes := map[string]map[string]bool{}
+	segments := make([][]string, len(tfs))
+	for i, tf := range tfs {
+		segments[i] = splitTF(tf)
+		prefix := ""
+		for _, segment := range segments[i] {
+			if strings.HasPrefix(segment, "#") {
+				if indexes[prefix] == nil {
+					indexes[prefix] = map[string]bool{}
+				}
+				indexes[prefix][segment[1:]] = true
+			}
+			prefix += segment
+		}
+	}
+	sparse := map[string]bool{}
+	for prefix, set := range indexes {
+		for index := range set {
+			if _, err := strconv.ParseInt(index, 0, 64); err != nil {
+				sparse[prefix] = false
+				break
+			}
+			if n, err := strconv.Atoi(index); err != nil || n < 0 || n >= len(set) || strconv.Itoa(n) != index {
+				sparse[prefix] = true
+			}
+		}
+	}
+	result := make([]string, len(tfs))
+	for i := range tfs {
+		var converted strings.Builder
+		prefix := ""
+		for _, segment := range segments[i] {
+			if strings.HasPrefix(segment, "#") && sparse[prefix] {
+				converted.WriteString("." + segment[1:])
+			} else {
+				converted.WriteString(segment)
+			}
+			prefix += segment
+		}
+		result[i] = converted.String()
+	}
+	return result
+}
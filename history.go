@@ -0,0 +1,415 @@
+/*
+AnyType Library for Go
+Undo/redo history of observable objects
+*/
+
+package anytype
+
+import (
+	"fmt"
+)
+
+/*
+History records changes of an observable object, so they can be undone and redone.
+Each call of a method changing the object (including nested structures) is recorded as a single step,
+all changes within a batch or a transaction form a single step as well.
+
+Implements:
+  - recorder.
+*/
+type History struct {
+	target      *ObservableObject
+	undo        [][]Event
+	redo        [][]Event
+	current     []Event
+	checkpoints map[string]int
+	restoring   bool
+}
+
+/*
+NewHistory starts recording changes of an observable object.
+
+Parameters:
+  - obj - object to record.
+
+Returns:
+  - pointer to the created history.
+*/
+func NewHistory(obj *ObservableObject) *History {
+	ego := &History{
+		target:      obj,
+		undo:        [][]Event{},
+		redo:        [][]Event{},
+		checkpoints: map[string]int{},
+	}
+	obj.hub.addRecorder(ego)
+	return ego
+}
+
+/*
+Defined in the recorder interface.
+Stores an event into the current step, unless the history itself is performing the change.
+
+Parameters:
+  - event - the event.
+*/
+func (ego *History) record(event Event) {
+	if !ego.restoring {
+		ego.current = append(ego.current, event)
+	}
+}
+
+/*
+Defined in the recorder interface.
+Closes the current step. Performing a new step discards all undone steps.
+*/
+func (ego *History) commit() {
+	if len(ego.current) == 0 {
+		return
+	}
+	for name, position := range ego.checkpoints {
+		if position > len(ego.undo) {
+			delete(ego.checkpoints, name)
+		}
+	}
+	ego.undo = append(ego.undo, ego.current)
+	ego.current = nil
+	ego.redo = [][]Event{}
+}
+
+/*
+Finds a container and the last segment of a tree form path within the recorded object.
+
+Parameters:
+  - path - tree form path of the change.
+
+Returns:
+  - object or list containing the changed value,
+  - last segment of the path (empty string if the path points to the root).
+*/
+func (ego *History) locate(path string) (any, string) {
+	var container any = ego.target.Ego()
+	if path == "" {
+		return container, ""
+	}
+	segments := splitTF(path)
+	for _, segment := range segments[:len(segments)-1] {
+		switch c := container.(type) {
+		case Object:
+			container = c.Get(segment[1:])
+		case List:
+			container = c.Get(parseIndex(segment[1:]))
+		}
+	}
+	return container, segments[len(segments)-1]
+}
+
+/*
+Replaces the whole content of a structure with a given one.
+
+Parameters:
+  - container - object or list to change,
+  - content - object or list with the new content.
+*/
+func restoreContent(container any, content any) {
+	switch c := container.(type) {
+	case Object:
+		c.Clear()
+		content.(Object).ForEach(func(key string, val any) {
+			c.Set(key, val)
+		})
+	case List:
+		c.Clear()
+		c.Add(content.(List).Slice()...)
+	}
+}
+
+/*
+Performs a change opposite to the given event.
+The recorded value is copied, so the step can be reverted repeatedly.
+
+Parameters:
+  - event - event to revert.
+*/
+func (ego *History) revert(event Event) {
+	event.Old = snapshot(event.Old)
+	container, segment := ego.locate(event.Path)
+	switch event.Op {
+	case OpClear:
+		if segment != "" {
+			container = getSegment(container, segment)
+		}
+		restoreContent(container, event.Old)
+	case OpSort, OpReverse, OpMove:
+		getSegment(container, segment).(*ObservableList).restoreOrder(event.Op, event.Old.(List))
+	case OpAdd, OpInsert:
+		switch c := container.(type) {
+		case Object:
+			c.Unset(segment[1:])
+		case List:
+			c.Delete(parseIndex(segment[1:]))
+		}
+	case OpReplace:
+		switch c := container.(type) {
+		case Object:
+			c.Set(segment[1:], event.Old)
+		case List:
+			c.Replace(parseIndex(segment[1:]), event.Old)
+		}
+	case OpDelete:
+		switch c := container.(type) {
+		case Object:
+			c.Set(segment[1:], event.Old)
+		case List:
+			c.Insert(parseIndex(segment[1:]), event.Old)
+		}
+	}
+}
+
+/*
+Performs the change described by the given event again.
+The recorded value is copied, so the step can be performed repeatedly.
+
+Parameters:
+  - event - event to apply.
+*/
+func (ego *History) apply(event Event) {
+	event.New = snapshot(event.New)
+	container, segment := ego.locate(event.Path)
+	switch event.Op {
+	case OpClear:
+		if segment != "" {
+			container = getSegment(container, segment)
+		}
+		if obj, ok := container.(Object); ok {
+			obj.Clear()
+		} else {
+			container.(List).Clear()
+		}
+	case OpSort, OpReverse, OpMove:
+		getSegment(container, segment).(*ObservableList).restoreOrder(event.Op, event.New.(List))
+	case OpAdd, OpInsert:
+		switch c := container.(type) {
+		case Object:
+			c.Set(segment[1:], event.New)
+		case List:
+			c.Insert(parseIndex(segment[1:]), event.New)
+		}
+	case OpReplace:
+		switch c := container.(type) {
+		case Object:
+			c.Set(segment[1:], event.New)
+		case List:
+			c.Replace(parseIndex(segment[1:]), event.New)
+		}
+	case OpDelete:
+		switch c := container.(type) {
+		case Object:
+			c.Unset(segment[1:])
+		case List:
+			c.Delete(parseIndex(segment[1:]))
+		}
+	}
+}
+
+/*
+Acquires a value specified by a single tree form segment.
+
+Parameters:
+  - container - object or list,
+  - segment - tree form segment.
+
+Returns:
+  - corresponding value.
+*/
+func getSegment(container any, segment string) any {
+	switch c := container.(type) {
+	case Object:
+		return c.Get(segment[1:])
+	case List:
+		return c.Get(parseIndex(segment[1:]))
+	}
+	return nil
+}
+
+/*
+Executes a function changing the object without recording the changes.
+Observers are notified about all the changes at once.
+
+Parameters:
+  - function - function to execute.
+*/
+func (ego *History) restore(function func()) {
+	ego.restoring = true
+	defer func() {
+		ego.restoring = false
+	}()
+	ego.target.hub.run(function)
+}
+
+/*
+Undo reverts the last recorded step.
+
+Returns:
+  - true if a step has been reverted, false if there is nothing to undo.
+*/
+func (ego *History) Undo() bool {
+	if len(ego.undo) == 0 {
+		return false
+	}
+	step := ego.undo[len(ego.undo)-1]
+	ego.undo = ego.undo[:len(ego.undo)-1]
+	ego.restore(func() {
+		for i := len(step) - 1; i >= 0; i-- {
+			ego.revert(step[i])
+		}
+	})
+	ego.redo = append(ego.redo, step)
+	return true
+}
+
+/*
+Redo performs the last undone step again.
+
+Returns:
+  - true if a step has been performed, false if there is nothing to redo.
+*/
+func (ego *History) Redo() bool {
+	if len(ego.redo) == 0 {
+		return false
+	}
+	step := ego.redo[len(ego.redo)-1]
+	ego.redo = ego.redo[:len(ego.redo)-1]
+	ego.restore(func() {
+		for _, event := range step {
+			ego.apply(event)
+		}
+	})
+	ego.undo = append(ego.undo, step)
+	return true
+}
+
+/*
+CanUndo checks whether there is a step to undo.
+
+Returns:
+  - true if Undo would revert a step, false otherwise.
+*/
+func (ego *History) CanUndo() bool {
+	return len(ego.undo) > 0
+}
+
+/*
+CanRedo checks whether there is a step to redo.
+
+Returns:
+  - true if Redo would perform a step, false otherwise.
+*/
+func (ego *History) CanRedo() bool {
+	return len(ego.redo) > 0
+}
+
+/*
+Checkpoint names the current state of the object, so it can be restored later by RevertTo.
+If the checkpoint already exists, it is moved to the current state.
+Checkpoints pointing to undone steps are discarded once a new step is recorded.
+
+Parameters:
+  - name - name of the checkpoint.
+
+Returns:
+  - unchanged history.
+*/
+func (ego *History) Checkpoint(name string) *History {
+	ego.checkpoints[name] = len(ego.undo)
+	return ego
+}
+
+/*
+RevertTo undoes or redoes steps until the object reaches the state of the given checkpoint.
+Causes a panic if the checkpoint does not exist.
+
+Parameters:
+  - name - name of the checkpoint.
+
+Returns:
+  - unchanged history.
+*/
+func (ego *History) RevertTo(name string) *History {
+	position, exists := ego.checkpoints[name]
+	if !exists {
+		panic(fmt.Sprintf("history does not have a checkpoint '%s'", name))
+	}
+	for len(ego.undo) > position {
+		ego.Undo()
+	}
+	for len(ego.undo) < position {
+		ego.Redo()
+	}
+	return ego
+}
+
+/*
+Clear forgets all recorded steps and checkpoints. The object remains unchanged.
+
+Returns:
+  - unchanged history.
+*/
+func (ego *History) Clear() *History {
+	ego.undo = [][]Event{}
+	ego.redo = [][]Event{}
+	ego.checkpoints = map[string]int{}
+	return ego
+}
+
+/*
+Close stops recording changes of the object.
+
+Returns:
+  - unchanged history.
+*/
+func (ego *History) Close() *History {
+	ego.target.hub.removeRecorder(ego)
+	return ego
+}
+
+/*
+Transaction executes a function changing the object as a single step.
+If the function returns an error or panics, all its changes are reverted.
+Subscribers are notified about neither the reverted changes nor the reverting ones.
+The panic is propagated after the changes are reverted.
+
+Parameters:
+  - function - function to execute, gets the recorded object as a parameter.
+
+Returns:
+  - error returned by the function.
+*/
+func (ego *History) Transaction(function func(obj Object) error) (err error) {
+	ego.target.hub.run(func() {
+		start := len(ego.current)
+		position := ego.target.hub.position()
+		rollback := func() {
+			defer ego.target.hub.discard(position)
+			changes := ego.current[start:]
+			ego.current = ego.current[:start]
+			ego.restoring = true
+			defer func() {
+				ego.restoring = false
+			}()
+			for i := len(changes) - 1; i >= 0; i-- {
+				ego.revert(changes[i])
+			}
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				rollback()
+				panic(r)
+			}
+		}()
+		if err = function(ego.target.Ego()); err != nil {
+			rollback()
+		}
+	})
+	return
+}
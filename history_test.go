@@ -0,0 +1,236 @@
+package anytype_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestHistory(t *testing.T) {
+
+	t.Run("undoRedo", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("first", 1, "list", List(3, 1, 2)))
+		h := anytype.NewHistory(o)
+		states := []object{o.Clone()}
+		steps := []func(){
+			func() { o.Set("first", 2, "second", 2) },
+			func() { o.Unset("first") },
+			func() { o.GetList("list").Add(4, 5) },
+			func() { o.GetList("list").Insert(0, 0) },
+			func() { o.GetList("list").Replace(1, 5) },
+			func() { o.GetList("list").Delete(0, 2) },
+			func() { o.GetList("list").Sort() },
+			func() { o.GetList("list").Reverse() },
+			func() { o.SetTF(".nested.list#2.test", true) },
+			func() { o.UnsetTF(".nested.list#1") },
+			func() { o.GetObject("nested").Clear() },
+			func() { o.GetList("list").Clear() },
+			func() { o.Clear() },
+		}
+		for _, step := range steps {
+			step()
+			states = append(states, o.Clone())
+		}
+		for i := len(states) - 2; i >= 0; i-- {
+			if !h.Undo() || !o.Equals(states[i]) {
+				t.Errorf("undo of step %d does not work properly", i)
+			}
+		}
+		if h.Undo() || h.CanUndo() {
+			t.Error("undo should not be possible")
+		}
+		for i := 1; i < len(states); i++ {
+			if !h.Redo() || !o.Equals(states[i]) {
+				t.Errorf("redo of step %d does not work properly", i-1)
+			}
+		}
+		if h.Redo() || h.CanRedo() {
+			t.Error("redo should not be possible")
+		}
+	})
+
+	t.Run("discarding", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object())
+		h := anytype.NewHistory(o)
+		o.Set("first", 1)
+		h.Undo()
+		if !h.CanRedo() {
+			t.Error("redo should be possible")
+		}
+		o.Set("second", 2)
+		if h.CanRedo() {
+			t.Error("new change should discard undone steps")
+		}
+		h.Clear()
+		if h.CanUndo() {
+			t.Error("history has not been cleared")
+		}
+		h.Close()
+		o.Set("third", 3)
+		if h.CanUndo() {
+			t.Error("closed history should not record changes")
+		}
+	})
+
+	t.Run("checkpoints", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object())
+		h := anytype.NewHistory(o)
+		h.Checkpoint("empty")
+		o.Set("first", 1)
+		o.Set("second", 2)
+		h.Checkpoint("full")
+		o.Set("third", 3)
+		h.RevertTo("empty")
+		if !o.Empty() {
+			t.Error("reverting to a checkpoint does not work properly")
+		}
+		h.RevertTo("full")
+		if !o.Equals(Object("first", 1, "second", 2)) {
+			t.Error("redoing to a checkpoint does not work properly")
+		}
+		h.Undo()
+		o.Set("fourth", 4)
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("checkpoint pointing to discarded steps should not exist")
+			}
+		}()
+		h.RevertTo("full")
+	})
+
+	t.Run("structural", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("list", List(1, 2, 3, 4)))
+		h := anytype.NewHistory(o)
+		l := o.GetList("list")
+		l.Rotate(1)
+		l.Splice(1, 2, "a", "b", "c")
+		l.Fill(0, 0, 2)
+		if !l.Equals(List(0, 0, "b", "c", 3)) {
+			t.Fatal("structural operations do not work on observable list")
+		}
+		h.Undo()
+		h.Undo()
+		if !l.Equals(List(4, 1, 2, 3)) {
+			t.Error("undoing Fill or Splice does not work properly")
+		}
+		h.Undo()
+		if !l.Equals(List(1, 2, 3, 4)) || h.CanUndo() {
+			t.Error("undoing Rotate does not work properly")
+		}
+		h.Redo()
+		if !l.Equals(List(4, 1, 2, 3)) {
+			t.Error("redoing Rotate does not work properly")
+		}
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("list", List(Object("n", 2), Object("n", 1))))
+		h := anytype.NewHistory(o)
+		l := o.GetList("list")
+		l.SortBy(".n")
+		var events []anytype.Event
+		o.Subscribe("", func(e []anytype.Event) {
+			events = append(events, e...)
+		})
+		h.Undo()
+		if !l.Equals(List(Object("n", 2), Object("n", 1))) || len(events) != 1 || events[0].Op != anytype.OpSort || events[0].Path != ".list" {
+			t.Error("undoing Sort does not restore the order by a single event")
+		}
+		h.Redo()
+		if !l.Equals(List(Object("n", 1), Object("n", 2))) || len(events) != 2 || events[1].Op != anytype.OpSort {
+			t.Error("redoing Sort does not restore the order by a single event")
+		}
+		l.GetObject(0).Set("n", 3)
+		if len(events) != 3 || events[2].Path != ".list#0.n" || !h.Undo() || l.GetObject(0).GetInt("n") != 1 {
+			t.Error("elements are not observed after the order has been restored")
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("old", Object("a", 1)))
+		h := anytype.NewHistory(o)
+		o.Rename("old", "new")
+		o.GetObject("new").Set("b", 2)
+		h.Undo()
+		h.Undo()
+		if !o.Equals(Object("old", Object("a", 1))) || h.CanUndo() {
+			t.Error("renaming should be recorded as a single step")
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object())
+		h := anytype.NewHistory(o)
+		o.Batch(func() {
+			o.Set("first", 1)
+			o.SetTF(".nested.test", 2)
+		})
+		h.Undo()
+		if !o.Empty() || h.CanUndo() {
+			t.Error("batch should be recorded as a single step")
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("list", List(1, 2)))
+		h := anytype.NewHistory(o)
+		original := o.Clone()
+		events := 0
+		o.Subscribe("", func(e []anytype.Event) {
+			events += len(e)
+		})
+		err := h.Transaction(func(obj object) error {
+			obj.Set("first", 1)
+			obj.GetList("list").Delete(0)
+			return errors.New("failure")
+		})
+		if err == nil || !o.Equals(original) || h.CanUndo() {
+			t.Error("failed transaction has not been rolled back")
+		}
+		if events != 0 {
+			t.Error("changes of failed transaction have been delivered")
+		}
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("panic in transaction has not been propagated")
+				}
+			}()
+			h.Transaction(func(obj object) error {
+				obj.Set("first", 1)
+				obj.GetList("list").Get(5)
+				return nil
+			})
+		}()
+		if !o.Equals(original) || h.CanUndo() || events != 0 {
+			t.Error("panicking transaction has not been rolled back")
+		}
+		if err := h.Transaction(func(obj object) error {
+			obj.Set("first", 1).Set("second", 2)
+			return nil
+		}); err != nil {
+			t.Error("successful transaction should not return error")
+		}
+		h.Undo()
+		if !o.Equals(original) {
+			t.Error("transaction should be recorded as a single step")
+		}
+	})
+
+}
+
+func TestHistoryPanics(t *testing.T) {
+
+	catch := func(msg string) {
+		if r := recover(); r == nil {
+			t.Error(msg)
+		}
+	}
+
+	t.Run("invalidCheckpoint", func(t *testing.T) {
+		defer catch("reverting to non-existing checkpoint did not cause panic")
+		anytype.NewHistory(anytype.NewObservableObject(Object())).RevertTo("test")
+	})
+
+}
@@ -0,0 +1,242 @@
+/*
+AnyType Library for Go
+INI parser and writer
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Removes an inline comment (started by ';' or '#' after whitespace) from an unquoted INI value.
+
+Parameters:
+  - value - the value.
+
+Returns:
+  - value without the comment and surrounding whitespace.
+*/
+func stripINIComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if (value[i] == ';' || value[i] == '#') && (value[i-1] == ' ' || value[i-1] == '\t') {
+			value = value[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+/*
+Reads a double-quoted INI value.
+
+Parameters:
+  - value - the value including the quotes and anything following them.
+
+Returns:
+  - unquoted value,
+  - error if the value is not terminated, contains an invalid escape sequence or is followed by anything but a comment.
+*/
+func unquoteINIValue(value string) (string, error) {
+	var result strings.Builder
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '"':
+			if rest := strings.TrimLeft(value[i+1:], " \t"); rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return "", fmt.Errorf("unexpected '%s' after a quoted value", rest[:1])
+			}
+			return result.String(), nil
+		case '\\':
+			if i++; i == len(value) {
+				break
+			}
+			switch value[i] {
+			case '\\', '"', ';', '#':
+				result.WriteByte(value[i])
+			case 'n':
+				result.WriteByte('\n')
+			case 'r':
+				result.WriteByte('\r')
+			case 't':
+				result.WriteByte('\t')
+			default:
+				return "", fmt.Errorf("invalid escape sequence '\\%c'", value[i])
+			}
+		default:
+			result.WriteByte(value[i])
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted value")
+}
+
+/*
+ParseINI creates a new object from an INI file.
+Keys before the first section are stored directly in the object, sections become nested objects.
+Dotted section names and keys are mapped to nested objects (e.g. section "db.primary" to {"db": {"primary": {...}}}), all values are strings.
+Both '=' and ':' separate keys from values. Comments start by ';' or '#' at the beginning of a line or after whitespace.
+Values may be double-quoted (allowing escape sequences \\, \", \n, \r, \t, \; and \#) and continued on the next line by a trailing backslash.
+A repeated section extends the previous one, a repeated key overwrites the previous value.
+
+Parameters:
+  - ini - INI string to parse.
+
+Returns:
+  - created object,
+  - error if any occurred (with the line).
+*/
+func ParseINI(ini string) (Object, error) {
+	result := NewObject()
+	section := result
+	lines := strings.Split(strings.TrimPrefix(ini, "\ufeff"), "\n")
+	for i := 0; i < len(lines); i++ {
+		number := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		var err error
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			switch {
+			case end < 0:
+				err = fmt.Errorf("missing ']'")
+			case stripINIComment(" "+line[end+1:]) != "":
+				err = fmt.Errorf("unexpected '%s' after a section", strings.TrimSpace(line[end+1:])[:1])
+			default:
+				name := strings.TrimSpace(line[1:end])
+				if name == "" {
+					err = fmt.Errorf("empty section name")
+				} else {
+					section, err = dottedObject(result, strings.Split(name, "."))
+				}
+			}
+		} else {
+			separator := strings.IndexAny(line, "=:")
+			if separator < 0 {
+				return nil, fmt.Errorf("not a valid INI - expecting '=' or ':' on line %d", number)
+			}
+			key := strings.TrimRight(line[:separator], " \t")
+			value := strings.TrimLeft(line[separator+1:], " \t")
+			if strings.HasPrefix(value, "\"") {
+				value, err = unquoteINIValue(value)
+			} else {
+				value = stripINIComment(" " + value)
+				for strings.HasSuffix(value, "\\") && i+1 < len(lines) {
+					i++
+					value = strings.TrimRight(value[:len(value)-1], " \t") + " " + stripINIComment(" "+strings.TrimSpace(lines[i]))
+				}
+			}
+			if key == "" {
+				err = fmt.Errorf("empty key")
+			}
+			if err == nil {
+				err = setDottedKey(section, key, value)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a valid INI - %s on line %d", err, number)
+		}
+	}
+	return result, nil
+}
+
+/*
+Checks whether a string can be written as an INI key or a segment of a section name.
+
+Parameters:
+  - name - string to check.
+
+Returns:
+  - true if the string can be written, false otherwise.
+*/
+func validININame(name string) bool {
+	return name != "" && strings.TrimSpace(name) == name && !strings.ContainsAny(name, "[]=:;#\"\\\n\r")
+}
+
+/*
+Formats a value of INI, quoting it if necessary.
+
+Parameters:
+  - value - value to format.
+
+Returns:
+  - formatted value.
+*/
+func formatINIValue(value any) string {
+	str := formatFlatValue(value)
+	if strings.TrimSpace(str) == str && !strings.ContainsAny(str, "\"\\;#\n\r\t") {
+		return str
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(str) + `"`
+}
+
+/*
+Writes entries of a section, nested objects are written as subsequent sections.
+
+Parameters:
+  - result - builder to write to,
+  - object - object of the section,
+  - name - name of the section (empty for the keys before the first section).
+
+Returns:
+  - error if a key cannot be written.
+*/
+func writeINISection(result *strings.Builder, object Object, name string) error {
+	var sections []string
+	for _, key := range sortedKeysOf(object) {
+		if !validININame(key) {
+			return fmt.Errorf("key '%s' cannot be written in INI", key)
+		}
+		value := object.Get(key)
+		if _, ok := value.(Object); ok {
+			sections = append(sections, key)
+			continue
+		}
+		flat := NewObject(key, value).FlattenWith(FlattenOptions{Separator: "."})
+		for _, path := range sortedKeysOf(flat) {
+			switch item := flat.Get(path).(type) {
+			case Object, List:
+				continue
+			default:
+				result.WriteString(path + " = " + formatINIValue(item) + "\n")
+			}
+		}
+	}
+	for _, key := range sections {
+		if name != "" {
+			key = name + "." + key
+		}
+		if result.Len() > 0 {
+			result.WriteString("\n")
+		}
+		result.WriteString("[" + key + "]\n")
+		if err := writeINISection(result, object.GetObject(key[strings.LastIndexByte(key, '.')+1:]), key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ToINI exports an object to an INI file.
+Scalar values of the object are written before the first section, nested objects become sections (deeper objects have dotted names).
+Lists are flattened into dotted keys (list indexes become numeric segments). Keys are sorted alphabetically.
+Nil is written as an empty value, empty lists are omitted. Values which would not be read back unchanged are double-quoted.
+
+Parameters:
+  - object - object to export.
+
+Returns:
+  - INI string,
+  - error if a key cannot be written in INI.
+*/
+func ToINI(object Object) (string, error) {
+	var result strings.Builder
+	if err := writeINISection(&result, object, ""); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
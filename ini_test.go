@@ -0,0 +1,110 @@
+package anytype_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestINI(t *testing.T) {
+
+	t.Run("parse", func(t *testing.T) {
+		o, err := anytype.ParseINI("; comment\nname = app # inline\n\n[db]\r\nuser: admin\npassword = \"p;a#s\\\"s\" ; quoted\n" +
+			"[db.primary]\nhost = localhost\nlimits.max = 10\n\n[db]\nnote = very \\\n  long   \\\n  line\nempty =\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !o.Equals(Object(
+			"name", "app",
+			"db", Object(
+				"user", "admin",
+				"password", "p;a#s\"s",
+				"primary", Object("host", "localhost", "limits", Object("max", "10")),
+				"note", "very long line",
+				"empty", "",
+			),
+		)) {
+			t.Errorf("parsing of INI does not work properly: %s", o)
+		}
+		o, err = anytype.ParseINI("\ufeff[a] ; section\nx=1\nx=2\nurl=http://host/#anchor")
+		if err != nil || !o.Equals(Object("a", Object("x", "2", "url", "http://host/#anchor"))) {
+			t.Error("parsing of repeated keys does not work properly")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object(
+			"name", "app",
+			"tags", List("a", "b"),
+			"db", Object(
+				"port", 5432,
+				"ratio", 0.5,
+				"password", " p;a\"s ",
+				"primary", Object("host", "localhost"),
+				"empty", nil,
+			),
+			"none", Object(),
+		)
+		expected := "name = app\ntags.0 = a\ntags.1 = b\n\n[db]\nempty = \npassword = \" p;a\\\"s \"\nport = 5432\nratio = 0.5\n\n" +
+			"[db.primary]\nhost = localhost\n\n[none]\n"
+		str, err := anytype.ToINI(o)
+		if err != nil || str != expected {
+			t.Errorf("object is not exported properly:\n%s", str)
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o := Object(
+			"top", "value",
+			"a", Object("b", Object("c", Object("d", "deep")), "e", "tab\there"),
+			"f", Object("g", "#hash", "h", "back\\slash", "i", "line\nbreak"),
+		)
+		str, err := anytype.ToINI(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := anytype.ParseINI(str)
+		if err != nil || !parsed.Equals(o) {
+			t.Error("exported INI does not round-trip")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for ini, message := range map[string]string{
+			"[a":                     "on line 1",
+			"[]":                     "on line 1",
+			"[a] x":                  "on line 1",
+			"a=1\nb":                 "on line 2",
+			"= 1":                    "on line 1",
+			"a = \"open":             "on line 1",
+			"a = \"x\\q\"":           "on line 1",
+			"a = \"x\" y":            "on line 1",
+			"a=1\n[a]":               "on line 2",
+			"[a]\nb=1\n[a.b]":        "on line 3",
+			"[a..b]":                 "on line 1",
+			"; c\n\n[x]\na.b=1\na=2": "on line 5",
+		} {
+			_, err := anytype.ParseINI(ini)
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", ini)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), message) {
+				t.Errorf("wrong error reported for %q: %s", ini, err)
+			}
+		}
+		for _, o := range []anytype.Object{
+			Object("", 1),
+			Object("a=b", 1),
+			Object("a", Object(" b", 1)),
+			Object("[a]", Object()),
+			Object("a;b", "c"),
+		} {
+			if _, err := anytype.ToINI(o); err == nil {
+				t.Errorf("exporting of %s does not return expected error", o)
+			}
+		}
+	})
+
+}
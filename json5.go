@@ -0,0 +1,880 @@
+/*
+AnyType Library for Go
+JSON5 parser and writer
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*
+Regular expression of keys which can be written without quotes.
+*/
+var json5Identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+/*
+Comments holds comments of a JSON5 document.
+The comments are keyed by paths of the values they belong to, the paths are tree forms ("" for the root value).
+Each comment is kept with its delimiters (e.g. "// note" or "/* note *\/").
+
+Fields:
+  - Leading - comments on the lines preceding a value (or its key),
+  - Inline - comments following a value (and its comma) on the same line,
+  - Closing - comments preceding the closing bracket of an object or a list,
+  - Footer - comments following the root value on the next lines.
+*/
+type Comments struct {
+	Leading map[string][]string
+	Inline  map[string][]string
+	Closing map[string][]string
+	Footer  []string
+}
+
+/*
+Comment read by the JSON5 parser.
+*/
+type json5Comment struct {
+	text   string
+	inline bool
+}
+
+/*
+Recursive descent parser of JSON5.
+*/
+type json5Parser struct {
+	src       string
+	pos       int
+	line      int
+	broken    bool
+	pending   []json5Comment
+	comments  Comments
+	newObject func(values ...any) Object
+}
+
+/*
+Creates an error with the current line number.
+
+Parameters:
+  - format - format of the message,
+  - args... - arguments of the message.
+
+Returns:
+  - created error.
+*/
+func (ego *json5Parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("not a valid JSON5 - %s on line %d", fmt.Sprintf(format, args...), ego.line)
+}
+
+/*
+Acquires the character at the current position.
+
+Returns:
+  - the character (0 at the end of the input),
+  - size of the character in bytes.
+*/
+func (ego *json5Parser) peek() (rune, int) {
+	if ego.pos >= len(ego.src) {
+		return 0, 0
+	}
+	return utf8.DecodeRuneInString(ego.src[ego.pos:])
+}
+
+/*
+Describes the character at the current position for error messages.
+
+Returns:
+  - description of the character.
+*/
+func (ego *json5Parser) unexpected() string {
+	char, size := ego.peek()
+	if size == 0 {
+		return "unexpected end of input"
+	}
+	return fmt.Sprintf("unexpected '%c'", char)
+}
+
+/*
+Checks whether a character is a JSON5 line terminator.
+
+Parameters:
+  - char - character to check.
+
+Returns:
+  - true if the character terminates a line, false otherwise.
+*/
+func isJSON5LineTerminator(char rune) bool {
+	return char == '\n' || char == '\r' || char == '\u2028' || char == '\u2029'
+}
+
+/*
+Skips whitespace and comments, the comments are added to the pending ones.
+
+Returns:
+  - error if a comment is not terminated.
+*/
+func (ego *json5Parser) skip() error {
+	for {
+		char, size := ego.peek()
+		switch {
+		case size == 0:
+			return nil
+		case isJSON5LineTerminator(char):
+			if char == '\n' {
+				ego.line++
+			}
+			ego.broken = true
+		case char == '\t' || char == '\v' || char == '\f' || char == '\ufeff' || unicode.Is(unicode.Zs, char):
+		case strings.HasPrefix(ego.src[ego.pos:], "//"):
+			end := strings.IndexFunc(ego.src[ego.pos:], isJSON5LineTerminator)
+			if end < 0 {
+				end = len(ego.src) - ego.pos
+			}
+			ego.pending = append(ego.pending, json5Comment{ego.src[ego.pos : ego.pos+end], !ego.broken})
+			ego.pos += end
+			continue
+		case strings.HasPrefix(ego.src[ego.pos:], "/*"):
+			end := strings.Index(ego.src[ego.pos+2:], "*/")
+			if end < 0 {
+				return ego.errorf("unterminated comment")
+			}
+			text := ego.src[ego.pos : ego.pos+end+4]
+			ego.pending = append(ego.pending, json5Comment{text, !ego.broken})
+			ego.line += strings.Count(text, "\n")
+			ego.pos += len(text)
+			continue
+		default:
+			return nil
+		}
+		ego.pos += size
+	}
+}
+
+/*
+Takes the pending comments.
+
+Parameters:
+  - inline - true to take only the comments on the line of the previous value, false to take all.
+
+Returns:
+  - texts of the comments (nil if there are none).
+*/
+func (ego *json5Parser) take(inline bool) []string {
+	var texts []string
+	i := 0
+	for ; i < len(ego.pending) && (!inline || ego.pending[i].inline); i++ {
+		texts = append(texts, ego.pending[i].text)
+	}
+	ego.pending = ego.pending[i:]
+	return texts
+}
+
+/*
+Stores comments to a map of comments.
+
+Parameters:
+  - target - the map (created if nil),
+  - path - path of the value,
+  - texts - texts of the comments.
+*/
+func storeComments(target *map[string][]string, path string, texts []string) {
+	if len(texts) == 0 {
+		return
+	}
+	if *target == nil {
+		*target = make(map[string][]string)
+	}
+	(*target)[path] = texts
+}
+
+/*
+Skips whitespace and comments following a value, the comments on the same line are stored as inline comments.
+
+Parameters:
+  - path - path of the value,
+  - comma - whether a comma may follow.
+
+Returns:
+  - true if a comma has been consumed,
+  - error if any occurred.
+*/
+func (ego *json5Parser) afterValue(path string, comma bool) (bool, error) {
+	ego.broken = false
+	if err := ego.skip(); err != nil {
+		return false, err
+	}
+	consumed := false
+	if char, _ := ego.peek(); comma && char == ',' {
+		ego.pos++
+		consumed = true
+		if err := ego.skip(); err != nil {
+			return false, err
+		}
+	}
+	storeComments(&ego.comments.Inline, path, ego.take(true))
+	return consumed, nil
+}
+
+/*
+Parses a value.
+
+Parameters:
+  - path - path of the value.
+
+Returns:
+  - parsed value,
+  - error if any occurred.
+*/
+func (ego *json5Parser) parseValue(path string) (any, error) {
+	char, _ := ego.peek()
+	switch {
+	case char == '{':
+		return ego.parseObject(path)
+	case char == '[':
+		return ego.parseList(path)
+	case char == '"' || char == '\'':
+		return ego.readString()
+	case char == '+' || char == '-' || char == '.' || (char >= '0' && char <= '9'):
+		return ego.readNumber()
+	}
+	start := ego.pos
+	word := ego.readIdentifierChars()
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	ego.pos = start
+	return nil, ego.errorf("%s", ego.unexpected())
+}
+
+/*
+Parses an object.
+
+Parameters:
+  - path - path of the object.
+
+Returns:
+  - parsed object,
+  - error if any occurred.
+*/
+func (ego *json5Parser) parseObject(path string) (Object, error) {
+	ego.pos++
+	result := ego.newObject()
+	for {
+		if err := ego.skip(); err != nil {
+			return nil, err
+		}
+		if char, _ := ego.peek(); char == '}' {
+			storeComments(&ego.comments.Closing, path, ego.take(false))
+			ego.pos++
+			return result, nil
+		}
+		key, err := ego.readKey()
+		if err != nil {
+			return nil, err
+		}
+		member := path + "." + key
+		storeComments(&ego.comments.Leading, member, ego.take(false))
+		if err := ego.skip(); err != nil {
+			return nil, err
+		}
+		if char, _ := ego.peek(); char != ':' {
+			return nil, ego.errorf("expecting ':', %s", ego.unexpected())
+		}
+		ego.pos++
+		if err := ego.skip(); err != nil {
+			return nil, err
+		}
+		value, err := ego.parseValue(member)
+		if err != nil {
+			return nil, err
+		}
+		result.Set(key, value)
+		comma, err := ego.afterValue(member, true)
+		if err != nil {
+			return nil, err
+		}
+		if char, _ := ego.peek(); !comma && char != '}' {
+			return nil, ego.errorf("expecting ',' or '}', %s", ego.unexpected())
+		}
+	}
+}
+
+/*
+Parses a list.
+
+Parameters:
+  - path - path of the list.
+
+Returns:
+  - parsed list,
+  - error if any occurred.
+*/
+func (ego *json5Parser) parseList(path string) (List, error) {
+	ego.pos++
+	result := NewList()
+	for {
+		if err := ego.skip(); err != nil {
+			return nil, err
+		}
+		if char, _ := ego.peek(); char == ']' {
+			storeComments(&ego.comments.Closing, path, ego.take(false))
+			ego.pos++
+			return result, nil
+		}
+		element := path + "#" + strconv.Itoa(result.Count())
+		storeComments(&ego.comments.Leading, element, ego.take(false))
+		value, err := ego.parseValue(element)
+		if err != nil {
+			return nil, err
+		}
+		result.Add(value)
+		comma, err := ego.afterValue(element, true)
+		if err != nil {
+			return nil, err
+		}
+		if char, _ := ego.peek(); !comma && char != ']' {
+			return nil, ego.errorf("expecting ',' or ']', %s", ego.unexpected())
+		}
+	}
+}
+
+/*
+Checks whether a character can start an identifier.
+
+Parameters:
+  - char - character to check.
+
+Returns:
+  - true if the character can start an identifier, false otherwise.
+*/
+func isJSON5IdentifierStart(char rune) bool {
+	return char == '$' || char == '_' || unicode.IsLetter(char) || unicode.Is(unicode.Nl, char)
+}
+
+/*
+Checks whether a character can be a part of an identifier.
+
+Parameters:
+  - char - character to check.
+
+Returns:
+  - true if the character can be a part of an identifier, false otherwise.
+*/
+func isJSON5IdentifierPart(char rune) bool {
+	return isJSON5IdentifierStart(char) || unicode.In(char, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc) ||
+		char == '\u200c' || char == '\u200d'
+}
+
+/*
+Reads characters of an identifier (without escape sequences).
+
+Returns:
+  - the characters (empty string if there is no identifier).
+*/
+func (ego *json5Parser) readIdentifierChars() string {
+	start := ego.pos
+	for {
+		char, size := ego.peek()
+		if size == 0 || !isJSON5IdentifierPart(char) || (ego.pos == start && !isJSON5IdentifierStart(char)) {
+			return ego.src[start:ego.pos]
+		}
+		ego.pos += size
+	}
+}
+
+/*
+Reads a key of an object member, either a string or an identifier.
+
+Returns:
+  - the key,
+  - error if any occurred.
+*/
+func (ego *json5Parser) readKey() (string, error) {
+	if char, _ := ego.peek(); char == '"' || char == '\'' {
+		return ego.readString()
+	}
+	var key strings.Builder
+	for {
+		char, size := ego.peek()
+		if char == '\\' {
+			if ego.pos+1 >= len(ego.src) || ego.src[ego.pos+1] != 'u' {
+				return "", ego.errorf("invalid escape sequence in a key")
+			}
+			ego.pos += 2
+			escaped, err := ego.readUnicodeEscape()
+			if err != nil {
+				return "", err
+			}
+			if !isJSON5IdentifierPart(escaped) || (key.Len() == 0 && !isJSON5IdentifierStart(escaped)) {
+				return "", ego.errorf("invalid character in a key")
+			}
+			key.WriteRune(escaped)
+			continue
+		}
+		if size == 0 || !isJSON5IdentifierPart(char) || (key.Len() == 0 && !isJSON5IdentifierStart(char)) {
+			break
+		}
+		key.WriteRune(char)
+		ego.pos += size
+	}
+	if key.Len() == 0 {
+		return "", ego.errorf("expecting a key, %s", ego.unexpected())
+	}
+	return key.String(), nil
+}
+
+/*
+Reads four hexadecimal digits of a unicode escape sequence (after "\u"), including a following low surrogate.
+
+Returns:
+  - the character,
+  - error if any occurred.
+*/
+func (ego *json5Parser) readUnicodeEscape() (rune, error) {
+	readHex := func() (rune, error) {
+		if ego.pos+4 > len(ego.src) {
+			return 0, ego.errorf("invalid unicode escape sequence")
+		}
+		code, err := strconv.ParseUint(ego.src[ego.pos:ego.pos+4], 16, 16)
+		if err != nil {
+			return 0, ego.errorf("invalid unicode escape sequence")
+		}
+		ego.pos += 4
+		return rune(code), nil
+	}
+	char, err := readHex()
+	if err != nil {
+		return 0, err
+	}
+	if char >= 0xd800 && char < 0xdc00 && strings.HasPrefix(ego.src[ego.pos:], "\\u") {
+		start := ego.pos
+		ego.pos += 2
+		low, err := readHex()
+		if err == nil && low >= 0xdc00 && low < 0xe000 {
+			return (char-0xd800)<<10 + (low - 0xdc00) + 0x10000, nil
+		}
+		ego.pos = start
+	}
+	return char, nil
+}
+
+/*
+Reads a single-quoted or double-quoted string, including escape sequences and line continuations.
+
+Returns:
+  - the string,
+  - error if any occurred.
+*/
+func (ego *json5Parser) readString() (string, error) {
+	quote := rune(ego.src[ego.pos])
+	ego.pos++
+	var result strings.Builder
+	for {
+		char, size := ego.peek()
+		switch {
+		case size == 0:
+			return "", ego.errorf("unterminated string")
+		case char == quote:
+			ego.pos++
+			return result.String(), nil
+		case char == '\n' || char == '\r':
+			return "", ego.errorf("line break in a string")
+		case char != '\\':
+			result.WriteRune(char)
+			ego.pos += size
+			continue
+		}
+		ego.pos++
+		char, size = ego.peek()
+		ego.pos += size
+		switch char {
+		case 'b':
+			result.WriteByte('\b')
+		case 'f':
+			result.WriteByte('\f')
+		case 'n':
+			result.WriteByte('\n')
+		case 'r':
+			result.WriteByte('\r')
+		case 't':
+			result.WriteByte('\t')
+		case 'v':
+			result.WriteByte('\v')
+		case '0':
+			if next, _ := ego.peek(); next >= '0' && next <= '9' {
+				return "", ego.errorf("invalid escape sequence")
+			}
+			result.WriteByte(0)
+		case 'x':
+			if ego.pos+2 > len(ego.src) {
+				return "", ego.errorf("invalid escape sequence")
+			}
+			code, err := strconv.ParseUint(ego.src[ego.pos:ego.pos+2], 16, 8)
+			if err != nil {
+				return "", ego.errorf("invalid escape sequence")
+			}
+			result.WriteRune(rune(code))
+			ego.pos += 2
+		case 'u':
+			escaped, err := ego.readUnicodeEscape()
+			if err != nil {
+				return "", err
+			}
+			result.WriteRune(escaped)
+		case '\n', '\u2028', '\u2029':
+			if char == '\n' {
+				ego.line++
+			}
+		case '\r':
+			if next, _ := ego.peek(); next == '\n' {
+				ego.pos++
+				ego.line++
+			}
+		case 0:
+			return "", ego.errorf("unterminated string")
+		default:
+			if char >= '1' && char <= '9' {
+				return "", ego.errorf("invalid escape sequence")
+			}
+			result.WriteRune(char)
+		}
+	}
+}
+
+/*
+Reads a number, including hexadecimal numbers, Infinity and NaN.
+
+Returns:
+  - the number (int or float64),
+  - error if any occurred.
+*/
+func (ego *json5Parser) readNumber() (any, error) {
+	start := ego.pos
+	sign := 1.0
+	if char := ego.src[ego.pos]; char == '+' || char == '-' {
+		if char == '-' {
+			sign = -1
+		}
+		ego.pos++
+	}
+	digits := func() int {
+		from := ego.pos
+		for ego.pos < len(ego.src) && ego.src[ego.pos] >= '0' && ego.src[ego.pos] <= '9' {
+			ego.pos++
+		}
+		return ego.pos - from
+	}
+	rest := ego.src[ego.pos:]
+	var value any
+	switch {
+	case strings.HasPrefix(rest, "Infinity"):
+		ego.pos += len("Infinity")
+		value = math.Inf(int(sign))
+	case strings.HasPrefix(rest, "NaN"):
+		ego.pos += len("NaN")
+		value = math.NaN()
+	case strings.HasPrefix(rest, "0x") || strings.HasPrefix(rest, "0X"):
+		ego.pos += 2
+		from := ego.pos
+		for ego.pos < len(ego.src) && strings.IndexByte("0123456789abcdefABCDEF", ego.src[ego.pos]) >= 0 {
+			ego.pos++
+		}
+		number, err := strconv.ParseInt(ego.src[start:from-2]+ego.src[from:ego.pos], 16, strconv.IntSize)
+		if err != nil {
+			ego.pos = start
+			return nil, ego.errorf("invalid number")
+		}
+		value = int(number)
+	default:
+		integer := digits()
+		if integer > 1 && ego.src[ego.pos-integer] == '0' {
+			ego.pos = start
+			return nil, ego.errorf("invalid number")
+		}
+		fraction := -1
+		if ego.pos < len(ego.src) && ego.src[ego.pos] == '.' {
+			ego.pos++
+			fraction = digits()
+		}
+		if integer == 0 && fraction <= 0 {
+			ego.pos = start
+			return nil, ego.errorf("invalid number")
+		}
+		exponent := false
+		if ego.pos < len(ego.src) && (ego.src[ego.pos] == 'e' || ego.src[ego.pos] == 'E') {
+			ego.pos++
+			if ego.pos < len(ego.src) && (ego.src[ego.pos] == '+' || ego.src[ego.pos] == '-') {
+				ego.pos++
+			}
+			if digits() == 0 {
+				ego.pos = start
+				return nil, ego.errorf("invalid number")
+			}
+			exponent = true
+		}
+		text := ego.src[start:ego.pos]
+		if fraction < 0 && !exponent {
+			if number, err := strconv.ParseInt(text, 10, strconv.IntSize); err == nil {
+				value = int(number)
+				break
+			}
+		}
+		number, err := strconv.ParseFloat(strings.TrimPrefix(text, "+"), 64)
+		if err != nil && !math.IsInf(number, 0) {
+			ego.pos = start
+			return nil, ego.errorf("invalid number")
+		}
+		value = number
+	}
+	if char, size := ego.peek(); size > 0 && (isJSON5IdentifierPart(char) || char == '.') {
+		return nil, ego.errorf("%s", ego.unexpected())
+	}
+	return value, nil
+}
+
+/*
+Parses a whole JSON5 document.
+
+Parameters:
+  - json5 - JSON5 string to parse,
+  - newObject - constructor of the parsed objects.
+
+Returns:
+  - the parser (holding the comments),
+  - parsed value,
+  - error if any occurred.
+*/
+func parseJSON5(json5 string, newObject func(values ...any) Object) (*json5Parser, any, error) {
+	if !utf8.ValidString(json5) {
+		return nil, nil, fmt.Errorf("not an UTF-8 encoding")
+	}
+	ego := &json5Parser{src: json5, line: 1, newObject: newObject}
+	if err := ego.skip(); err != nil {
+		return nil, nil, err
+	}
+	storeComments(&ego.comments.Leading, "", ego.take(false))
+	value, err := ego.parseValue("")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := ego.afterValue("", false); err != nil {
+		return nil, nil, err
+	}
+	ego.comments.Footer = ego.take(false)
+	if ego.pos < len(ego.src) {
+		return nil, nil, ego.errorf("%s after the value", ego.unexpected())
+	}
+	return ego, value, nil
+}
+
+/*
+ParseJSON5 parses a JSON5 document, which may contain comments, trailing commas, unquoted identifier keys,
+single-quoted strings, line continuations in strings, hexadecimal numbers, Infinity and NaN.
+Strict JSON and JSON with comments (JSONC) are subsets of JSON5.
+The comments are collected, so the document can be written back by ToJSON5.
+
+Parameters:
+  - json5 - JSON5 string to parse.
+
+Returns:
+  - parsed value (Object, List or a scalar),
+  - comments of the document,
+  - error if any occurred (with the line).
+*/
+func ParseJSON5(json5 string) (any, Comments, error) {
+	parser, value, err := parseJSON5(json5, NewObject)
+	if err != nil {
+		return nil, Comments{}, err
+	}
+	return value, parser.comments, nil
+}
+
+/*
+Formats a string in JSON5, using double quotes.
+
+Parameters:
+  - str - string to format.
+
+Returns:
+  - quoted string.
+*/
+func formatJSON5String(str string) string {
+	var result strings.Builder
+	result.WriteByte('"')
+	for _, char := range str {
+		switch char {
+		case '"':
+			result.WriteString(`\"`)
+		case '\\':
+			result.WriteString(`\\`)
+		case '\b':
+			result.WriteString(`\b`)
+		case '\f':
+			result.WriteString(`\f`)
+		case '\n':
+			result.WriteString(`\n`)
+		case '\r':
+			result.WriteString(`\r`)
+		case '\t':
+			result.WriteString(`\t`)
+		default:
+			if char < 0x20 || char == 0x7f || char == '\u2028' || char == '\u2029' {
+				fmt.Fprintf(&result, `\u%04x`, char)
+			} else {
+				result.WriteRune(char)
+			}
+		}
+	}
+	result.WriteByte('"')
+	return result.String()
+}
+
+/*
+Writer of JSON5 documents.
+*/
+type json5Writer struct {
+	result   strings.Builder
+	comments Comments
+}
+
+/*
+Writes comments, each on its own line.
+
+Parameters:
+  - texts - texts of the comments,
+  - indent - indentation of the lines.
+*/
+func (ego *json5Writer) writeComments(texts []string, indent string) {
+	for _, text := range texts {
+		ego.result.WriteString(indent + text + "\n")
+	}
+}
+
+/*
+Writes inline comments of a value and terminates the line.
+
+Parameters:
+  - path - path of the value.
+*/
+func (ego *json5Writer) endLine(path string) {
+	if texts := ego.comments.Inline[path]; len(texts) > 0 {
+		ego.result.WriteString(" " + strings.Join(texts, " "))
+	}
+	ego.result.WriteByte('\n')
+}
+
+/*
+Writes a value.
+
+Parameters:
+  - value - value to write,
+  - path - path of the value,
+  - indent - indentation of the line containing the value.
+*/
+func (ego *json5Writer) writeValue(value any, path string, indent string) {
+	switch typed := value.(type) {
+	case nil:
+		ego.result.WriteString("null")
+	case string:
+		ego.result.WriteString(formatJSON5String(typed))
+	case float64:
+		switch {
+		case math.IsNaN(typed):
+			ego.result.WriteString("NaN")
+		case math.IsInf(typed, 1):
+			ego.result.WriteString("Infinity")
+		case math.IsInf(typed, -1):
+			ego.result.WriteString("-Infinity")
+		default:
+			str := strconv.FormatFloat(typed, 'g', -1, 64)
+			if !strings.ContainsAny(str, ".e") {
+				str += ".0"
+			}
+			ego.result.WriteString(str)
+		}
+	case Object:
+		closing := ego.comments.Closing[path]
+		if typed.Empty() && len(closing) == 0 {
+			ego.result.WriteString("{}")
+			return
+		}
+		ego.result.WriteString("{\n")
+		keys := sortedKeysOf(typed)
+		for i, key := range keys {
+			member := path + "." + key
+			ego.writeComments(ego.comments.Leading[member], indent+"  ")
+			ego.result.WriteString(indent + "  ")
+			if json5Identifier.MatchString(key) {
+				ego.result.WriteString(key)
+			} else {
+				ego.result.WriteString(formatJSON5String(key))
+			}
+			ego.result.WriteString(": ")
+			ego.writeValue(typed.Get(key), member, indent+"  ")
+			if i < len(keys)-1 {
+				ego.result.WriteByte(',')
+			}
+			ego.endLine(member)
+		}
+		ego.writeComments(closing, indent+"  ")
+		ego.result.WriteString(indent + "}")
+	case List:
+		closing := ego.comments.Closing[path]
+		if typed.Empty() && len(closing) == 0 {
+			ego.result.WriteString("[]")
+			return
+		}
+		ego.result.WriteString("[\n")
+		for i := 0; i < typed.Count(); i++ {
+			element := path + "#" + strconv.Itoa(i)
+			ego.writeComments(ego.comments.Leading[element], indent+"  ")
+			ego.result.WriteString(indent + "  ")
+			ego.writeValue(typed.Get(i), element, indent+"  ")
+			if i < typed.Count()-1 {
+				ego.result.WriteByte(',')
+			}
+			ego.endLine(element)
+		}
+		ego.writeComments(closing, indent+"  ")
+		ego.result.WriteString(indent + "]")
+	default:
+		ego.result.WriteString(fmt.Sprint(value))
+	}
+}
+
+/*
+ToJSON5 exports a value to a JSON5 document indented by two spaces, re-emitting the given comments (e.g. acquired by ParseJSON5).
+Keys of objects are sorted alphabetically and written without quotes if they are identifiers.
+Comments of the values which do not exist are omitted.
+
+Parameters:
+  - value - value to export (object, list or any value storable in them),
+  - comments - comments to write (may be empty).
+
+Returns:
+  - JSON5 string.
+*/
+func ToJSON5(value any, comments Comments) string {
+	writer := &json5Writer{comments: comments}
+	writer.writeComments(comments.Leading[""], "")
+	writer.writeValue(parseVal(value).getVal(), "", "")
+	writer.endLine("")
+	writer.writeComments(comments.Footer, "")
+	return writer.result.String()
+}
@@ -0,0 +1,197 @@
+package anytype_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestJSON5(t *testing.T) {
+
+	t.Run("syntax", func(t *testing.T) {
+		value, _, err := anytype.ParseJSON5("\ufeff" + `{
+  unquoted: 'single "quoted"',
+  $dollar_1: "escapes \x41é😀\'\0\v",
+  multi: "first \
+second",
+  hex: 0xFF, negativeHex: -0x10,
+  float: .5, trailing: 5., exponent: 1e3, positive: +1,
+  special: [Infinity, -Infinity, NaN],
+  "quoted key": null, bool: true,
+  unicodeKey: { ключ: 1, a\u0062: 2 },
+  nested: [[],{},[1,],],
+}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := value.(anytype.Object)
+		special := o.GetList("special")
+		if !math.IsInf(special.GetFloat(0), 1) || !math.IsInf(special.GetFloat(1), -1) || !math.IsNaN(special.GetFloat(2)) {
+			t.Error("parsing of special numbers does not work properly")
+		}
+		if special.String() != "[+Inf,-Inf,NaN]" {
+			t.Error("special numbers are not serialized as documented")
+		}
+		o.Unset("special")
+		expected := Object(
+			"unquoted", `single "quoted"`,
+			"$dollar_1", "escapes Aé😀'\x00\v",
+			"multi", "first second",
+			"hex", 255,
+			"negativeHex", -16,
+			"float", 0.5,
+			"trailing", 5.0,
+			"exponent", 1000.0,
+			"positive", 1,
+			"quoted key", nil,
+			"bool", true,
+			"unicodeKey", Object("ключ", 1, "ab", 2),
+			"nested", List(List(), Object(), List(1)),
+		)
+		if !o.Equals(expected) {
+			t.Error("parsing of JSON5 does not work properly")
+		}
+		for json5, expected := range map[string]any{
+			"42":            42,
+			" -1.5 ":        -1.5,
+			"'text'":        "text",
+			"null":          nil,
+			"// only\ntrue": true,
+		} {
+			value, _, err := anytype.ParseJSON5(json5)
+			if err != nil || value != expected {
+				t.Errorf("parsing of %q does not work properly", json5)
+			}
+		}
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		value, comments, err := anytype.ParseJSON5(`// header
+{
+  /* about a */
+  a: 1, // inline a
+  b: [
+    2, /* two */ // second
+    // before close
+  ],
+  c: {},
+} // end
+/* footer */`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !value.(anytype.Object).Equals(Object("a", 1, "b", List(2), "c", Object())) {
+			t.Error("parsing of JSON5 with comments does not work properly")
+		}
+		expected := anytype.Comments{
+			Leading: map[string][]string{"": {"// header"}, ".a": {"/* about a */"}},
+			Inline:  map[string][]string{".a": {"// inline a"}, ".b#0": {"/* two */", "// second"}, "": {"// end"}},
+			Closing: map[string][]string{".b": {"// before close"}},
+			Footer:  []string{"/* footer */"},
+		}
+		if !reflect.DeepEqual(comments, expected) {
+			t.Errorf("comments are not collected properly: %+v", comments)
+		}
+		exported := anytype.ToJSON5(value, comments)
+		if exported != `// header
+{
+  /* about a */
+  a: 1, // inline a
+  b: [
+    2 /* two */ // second
+    // before close
+  ],
+  c: {}
+} // end
+/* footer */
+` {
+			t.Errorf("JSON5 is not exported properly:\n%s", exported)
+		}
+		parsed, parsedComments, err := anytype.ParseJSON5(exported)
+		if err != nil || !parsed.(anytype.Object).Equals(value.(anytype.Object)) || !reflect.DeepEqual(parsedComments, comments) {
+			t.Error("exported JSON5 does not round-trip")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object(
+			"plain", "line\nbreak \"quoted\" \x01\u2028",
+			"not-identifier", 2.0,
+			"inf", math.Inf(-1),
+			"list", List(1, List(), Object("x", nil)),
+		)
+		exported := anytype.ToJSON5(o, anytype.Comments{})
+		if !strings.Contains(exported, `  plain: "line\nbreak \"quoted\" \u0001\u2028"`) || !strings.Contains(exported, `  "not-identifier": 2.0,`) {
+			t.Errorf("object is not exported properly:\n%s", exported)
+		}
+		parsed, _, err := anytype.ParseJSON5(exported)
+		if err != nil || !parsed.(anytype.Object).Equals(o) {
+			t.Error("exported JSON5 does not round-trip")
+		}
+		if anytype.ToJSON5("text", anytype.Comments{}) != "\"text\"\n" {
+			t.Error("scalar is not exported properly")
+		}
+	})
+
+	t.Run("relaxed", func(t *testing.T) {
+		o, err := anytype.ParseObjectWith("{\n  // comment\n  Key: [1, 2,],\n}", anytype.ParserOptions{Relaxed: true, KeyNormalizer: anytype.FoldCase})
+		if err != nil || !o.Equals(Object("Key", List(1, 2))) || !o.KeyExists("KEY") {
+			t.Error("relaxed parsing of objects does not work properly")
+		}
+		l, err := anytype.ParseListWith("/* list */ ['a',]", anytype.ParserOptions{Relaxed: true})
+		if err != nil || !l.Equals(List("a")) {
+			t.Error("relaxed parsing of lists does not work properly")
+		}
+		if _, err := anytype.ParseObjectWith("[]", anytype.ParserOptions{Relaxed: true}); err == nil {
+			t.Error("relaxed parsing of an object does not return expected error for a list")
+		}
+		if _, err := anytype.ParseListWith("1", anytype.ParserOptions{Relaxed: true}); err == nil {
+			t.Error("relaxed parsing of a list does not return expected error for a scalar")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for json5, position := range map[string]string{
+			"{a: 1,,}":             "line 1",
+			"{a: 1\nb: 2}":         "line 2",
+			"[1 2]":                "line 1",
+			"{a 1}":                "line 1",
+			"{1a: 1}":              "line 1",
+			"{\n\n'a': 01}":        "line 3",
+			"\"line\nbreak\"":      "line 1",
+			"'unterminated":        "line 1",
+			"/* open":              "line 1",
+			"[\n/* a\n*/ wrong]":   "line 3",
+			"'\\1'":                "line 1",
+			"'\\xZZ'":              "line 1",
+			"'\\u12'":              "line 1",
+			"0x":                   "line 1",
+			"1.2.3":                "line 1",
+			"1e":                   "line 1",
+			"+":                    "line 1",
+			".":                    "line 1",
+			"{} {}":                "line 1",
+			"[1]\n\n2":             "line 3",
+			"nul":                  "line 1",
+			"":                     "line 1",
+			"{a: [1, {b: }]}":      "line 1",
+			"0xFFFFFFFFFFFFFFFFFF": "line 1",
+		} {
+			_, _, err := anytype.ParseJSON5(json5)
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", json5)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), position) {
+				t.Errorf("wrong position reported for %q: %s", json5, err)
+			}
+		}
+		if _, _, err := anytype.ParseJSON5("[\"\xff\"]"); err == nil {
+			t.Error("parser did not return expected error for invalid UTF-8")
+		}
+	})
+
+}
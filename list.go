@@ -5,6 +5,8 @@ List (array) type
 
 package anytype
 
+import "math/rand"
+
 /*
 List is an ordered sequence of elements.
 */
@@ -81,6 +83,108 @@ type List interface {
 	*/
 	Pop() List
 
+	/*
+		Shift deletes the first element of the list.
+		Causes a panic if the list is empty.
+
+		Returns:
+		  - updated list.
+	*/
+	Shift() List
+
+	/*
+		Unshift inserts new elements at the beginning of the list.
+
+		Parameters:
+		  - values... - any amount of elements to insert.
+
+		Returns:
+		  - updated list.
+	*/
+	Unshift(values ...any) List
+
+	/*
+		InsertAll inserts new elements at a given position in the list.
+		Negative index counts from the end of the list.
+
+		Parameters:
+		  - index - position where the first element should be inserted,
+		  - values... - any amount of elements to insert.
+
+		Returns:
+		  - updated list.
+	*/
+	InsertAll(index int, values ...any) List
+
+	/*
+		Splice deletes a given number of elements starting at a given position and inserts new elements in their place.
+		Negative start counts from the end of the list, the number of deleted elements is limited by the end of the list.
+		Causes a panic if the number of deleted elements is lower than zero.
+
+		Parameters:
+		  - start - position of the first deleted element,
+		  - deleteCount - number of elements to delete,
+		  - items... - any amount of elements to insert.
+
+		Returns:
+		  - new list containing the deleted elements.
+	*/
+	Splice(start int, deleteCount int, items ...any) List
+
+	/*
+		Swap exchanges two elements of the list.
+		Negative indexes count from the end of the list.
+
+		Parameters:
+		  - i - position of the first element,
+		  - j - position of the second element.
+
+		Returns:
+		  - updated list.
+	*/
+	Swap(i int, j int) List
+
+	/*
+		Move moves an element of the list to another position, the elements in between are shifted.
+		Negative indexes count from the end of the list.
+
+		Parameters:
+		  - from - current position of the element,
+		  - to - position of the element after the move.
+
+		Returns:
+		  - updated list.
+	*/
+	Move(from int, to int) List
+
+	/*
+		Rotate rotates the elements of the list by a given number of positions.
+		Positive number moves the elements towards the end (the last elements go to the beginning),
+		negative number moves them towards the beginning.
+
+		Parameters:
+		  - k - number of positions.
+
+		Returns:
+		  - updated list.
+	*/
+	Rotate(k int) List
+
+	/*
+		Fill replaces elements in a given range by a value.
+		Negative start counts from the end of the list, end is handled the same way as in SubList.
+		Objects and lists are copied into each position.
+
+		Parameters:
+		  - value - value to fill,
+		  - start - position of the first replaced element,
+		  - end - position after the last replaced element.
+
+		Returns:
+		  - updated list.
+	*/
+	Fill(value any, start int, end int) List
+
 	/*
 		Clear deletes all elements in the list.
 
@@ -186,6 +290,8 @@ type List interface {
 
 	/*
 		String gives a JSON representation of the list, including nested lists and objects.
+		Non-finite floats (e.g. parsed from JSON5 Infinity, TOML inf or YAML .inf) are written as +Inf, -Inf and NaN,
+		so the result is not valid JSON; ToJSON5 can be used to export them.
 
 		Returns:
 		  - JSON string.
@@ -360,353 +466,684 @@ type List interface {
 	IndexOf(elem any) int
 
 	/*
-		Sort sorts elements in the list (ascending).
-		The first element of the list determines the sorting type and has to be either string, int or float.
-		Values of other types are ignored.
-
-		Returns:
-		  - updated list.
-	*/
-	Sort() List
-
-	/*
-		Reverse reverses the order of elements in the list.
-
-		Returns:
-		  - updated list.
-	*/
-	Reverse() List
+		LastIndexOf gives a position of the last occurrence of a given element.
 
-	/*
-		AllObjects checks if the list is homogeneous and all of its elements are objects.
+		Parameters:
+		  - elem - the element to check.
 
 		Returns:
-		  - true if all elements are objects, false otherwise.
+		  - index of the element (-1 if the list does not contain the element).
 	*/
-	AllObjects() bool
+	LastIndexOf(elem any) int
 
 	/*
-		AllLists checks if the list is homogeneous and all of its elements are lists.
-
-		Returns:
-		  - true if all elements are lists, false otherwise.
-	*/
-	AllLists() bool
+		Find gives the first element satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
 
-	/*
-		AllStrings checks if the list is homogeneous and all of its elements are strings.
+		Parameters:
+		  - function - anonymous function to be executed.
 
 		Returns:
-		  - true if all elements are strings, false otherwise.
+		  - found element (nil if there is none),
+		  - true if the element has been found, false otherwise.
 	*/
-	AllStrings() bool
+	Find(function func(x any) bool) (any, bool)
 
 	/*
-		AllBools checks if the list is homogeneous and all of its elements are bools.
-
-		Returns:
-		  - true if all elements are bools, false otherwise.
-	*/
-	AllBools() bool
+		FindIndex gives a position of the first element satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
 
-	/*
-		AllInts checks if the list is homogeneous and all of its elements are ints.
+		Parameters:
+		  - function - anonymous function to be executed.
 
 		Returns:
-		  - true if all elements are ints, false otherwise.
+		  - index of the element (-1 if there is none).
 	*/
-	AllInts() bool
+	FindIndex(function func(x any) bool) int
 
 	/*
-		AllFloats checks if the list is homogeneous and all of its elements are floats.
-
-		Returns:
-		  - true if all elements are floats, false otherwise.
-	*/
-	AllFloats() bool
+		FindLast gives the last element satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
 
-	/*
-		AllNumeric checks if all elements of the list are numeric (ints or floats).
+		Parameters:
+		  - function - anonymous function to be executed.
 
 		Returns:
-		  - true if all elements are numeric, false otherwise.
+		  - found element (nil if there is none),
+		  - true if the element has been found, false otherwise.
 	*/
-	AllNumeric() bool
+	FindLast(function func(x any) bool) (any, bool)
 
 	/*
-		ForEach executes a given function over an every element of the list.
-		The function has two parameters: index of the current element and its value.
+		Any checks whether at least one element satisfies a condition.
+		The function has one parameter, the current element, and returns bool.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - unchanged list.
+		  - true if any element satisfies the condition, false otherwise (including an empty list).
 	*/
-	ForEach(function func(i int, val any)) List
+	Any(function func(x any) bool) bool
 
 	/*
-		ForEachValue executes a given function over an every element of the list.
-		The function has one parameter, value of the current element.
+		All checks whether all elements satisfy a condition.
+		The function has one parameter, the current element, and returns bool.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - unchanged list.
+		  - true if all elements satisfy the condition (including an empty list), false otherwise.
 	*/
-	ForEachValue(function func(x any)) List
+	All(function func(x any) bool) bool
 
 	/*
-		ForEachObject executes a given function over all objects in the list.
-		Elements with other types are ignored.
-		The function has one parameter, the current object.
+		None checks whether no element satisfies a condition.
+		The function has one parameter, the current element, and returns bool.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - unchanged list.
+		  - true if no element satisfies the condition (including an empty list), false otherwise.
 	*/
-	ForEachObject(function func(x Object)) List
+	None(function func(x any) bool) bool
 
 	/*
-		ForEachList executes a given function over all lists nested in the list.
-		Elements with other types are ignored.
-		The function has one parameter, the current list.
+		CountIf counts elements satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - unchanged list.
+		  - number of the elements.
 	*/
-	ForEachList(function func(x List)) List
+	CountIf(function func(x any) bool) int
 
 	/*
-		ForEachString executes a given function over all strings in the list.
-		Elements with other types are ignored.
-		The function has one parameter, the current string.
+		RemoveIf deletes all elements satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - unchanged list.
+		  - updated list.
 	*/
-	ForEachString(function func(x string)) List
+	RemoveIf(function func(x any) bool) List
 
 	/*
-		ForEachBool executes a given function over all bools in the list.
-		Elements with other types are ignored.
-		The function has one parameter, the current bool.
+		RetainIf deletes all elements not satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - unchanged list.
+		  - updated list.
 	*/
-	ForEachBool(function func(x bool)) List
+	RetainIf(function func(x any) bool) List
 
 	/*
-		ForEachInt executes a given function over all ints in the list.
-		Elements with other types are ignored.
-		The function has one parameter, the current int.
+		ContainsDeep checks if the list contains a given element.
+		Objects and lists are compared recursively (by value).
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - elem - the element to check.
 
 		Returns:
-		  - unchanged list.
+		  - true if the list contains the element, false otherwise.
 	*/
-	ForEachInt(function func(x int)) List
+	ContainsDeep(elem any) bool
 
 	/*
-		ForEachFloat executes a given function over all floats in the list.
-		Elements with other types are ignored.
-		The function has one parameter, the current float.
+		IndexOfDeep gives a position of the first occurrence of a given element.
+		Objects and lists are compared recursively (by value).
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - elem - the element to check.
 
 		Returns:
-		  - unchanged list.
+		  - index of the element (-1 if the list does not contain the element).
 	*/
-	ForEachFloat(function func(x float64)) List
+	IndexOfDeep(elem any) int
 
 	/*
-		Map copies the list and modifies each element by a given mapping function.
-		The resulting element can have a different type than the original one.
-		The function has two parameters: current index and value of the current element. Returns any.
+		Distinct creates a new list containing the elements of the old one without duplicates.
+		Objects and lists are compared recursively (by value), the first occurrence is kept.
 		The old list remains unchanged.
 
-		Parameters:
-		  - function - anonymous function to be executed.
-
 		Returns:
 		  - new list.
 	*/
-	Map(function func(i int, val any) any) List
+	Distinct() List
 
 	/*
-		MapValues copies the list and modifies each element by a given mapping function.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, value of the current element, and returns any.
+		DistinctBy creates a new list of objects with distinct values specified by a given tree form.
+		The first object with each value is kept, objects not containing the value are considered equal to each other.
 		The old list remains unchanged.
+		Causes a panic if any of the elements is not an object.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - tf - tree form of the compared value.
 
 		Returns:
 		  - new list.
 	*/
-	MapValues(function func(x any) any) List
+	DistinctBy(tf string) List
 
 	/*
-		MapObjects selects all objects from the list and modifies each of them by a given mapping function.
-		Elements of other types are ignored.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, the current object, and returns any.
-		The old list remains unchanged.
+		Union creates a new list containing distinct elements present in the list or in another list.
+		Objects and lists are compared recursively (by value).
+		The elements of the list go first, followed by the new elements of another list.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - another - the other list.
 
 		Returns:
 		  - new list.
 	*/
-	MapObjects(function func(x Object) any) List
+	Union(another List) List
 
 	/*
-		MapLists selects all nested lists from the list and modifies each of them by a given mapping function.
-		Elements of other types are ignored.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, the current list, and returns any.
-		The old list remains unchanged.
+		Intersect creates a new list containing distinct elements present both in the list and in another list.
+		Objects and lists are compared recursively (by value). The order of the list is kept.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - another - the other list.
 
 		Returns:
 		  - new list.
 	*/
-	MapLists(function func(x List) any) List
+	Intersect(another List) List
 
 	/*
-		MapStrings selects all strings from the list and modifies each of them by a given mapping function.
-		Elements of other types are ignored.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, the current string, and returns any.
-		The old list remains unchanged.
+		Difference creates a new list containing distinct elements of the list which are not present in another list.
+		Objects and lists are compared recursively (by value). The order of the list is kept.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - another - the other list.
 
 		Returns:
 		  - new list.
 	*/
-	MapStrings(function func(x string) any) List
+	Difference(another List) List
 
 	/*
-		MapBools selects all bools from the list and modifies each of them by a given mapping function.
-		Elements of other types are ignored.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, the current bool, and returns any.
-		The old list remains unchanged.
+		SymmetricDifference creates a new list containing distinct elements present in exactly one of the lists.
+		Objects and lists are compared recursively (by value).
+		The elements of the list go first, followed by the elements of another list.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - another - the other list.
 
 		Returns:
 		  - new list.
 	*/
-	MapBools(function func(x bool) any) List
+	SymmetricDifference(another List) List
 
 	/*
-		MapInts selects all ints from the list and modifies each of them by a given mapping function.
-		Elements of other types are ignored.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, the current int, and returns any.
-		The old list remains unchanged.
-
-		Parameters:
-		  - function - anonymous function to be executed.
+		Sort sorts elements in the list (ascending) according to the total ordering of values (see Compare).
+		Elements of different types can be mixed, equal elements keep their original order.
 
 		Returns:
-		  - new list.
+		  - updated list.
 	*/
-	MapInts(function func(x int) any) List
+	Sort() List
 
 	/*
-		MapFloats selects all floats from the list and modifies each of them by a given mapping function.
-		Elements of other types are ignored.
-		The resulting element can have a different type than the original one.
-		The function has one parameter, the current float, and returns any.
-		The old list remains unchanged.
+		SortFunc sorts elements in the list using a given comparator.
+		The sort is not guaranteed to be stable.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - less - function reporting whether the first value should precede the second one.
 
 		Returns:
-		  - new list.
+		  - updated list.
 	*/
-	MapFloats(function func(x float64) any) List
+	SortFunc(less func(a any, b any) bool) List
 
 	/*
-		Reduce reduces all elements of the list into a single value.
-		The function has two parameters: value returned by the previous iteration and value of the current element. Returns any.
-		The list remains unchanged.
+		SortStable sorts elements in the list using a given comparator.
+		Equal elements keep their original order.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - less - function reporting whether the first value should precede the second one.
 
 		Returns:
-		  - computed value.
+		  - updated list.
 	*/
-	Reduce(initial any, function func(acc any, val any) any) any
+	SortStable(less func(a any, b any) bool) List
 
 	/*
-		ReduceStrings reduces all strings in the list into a single string.
-		Elements of other types are ignored.
-		The function has two parameters: string returned by the previous iteration and current string. Returns string.
-		The list remains unchanged.
+		SortBy sorts a list of objects by values specified by the given tree forms (see Compare).
+		The first tree form is the primary key, the following ones are used when the previous keys are equal.
+		The tree form can be prefixed by '-' for descending order (or '+' for ascending, which is the default).
+		Objects not containing the value precede all others. The sort is stable.
+		Causes a panic if any of the elements is not an object.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - tfs... - tree forms of the sorting keys.
 
 		Returns:
-		  - computed value.
+		  - updated list.
 	*/
-	ReduceStrings(initial string, function func(acc string, val string) string) string
+	SortBy(tfs ...string) List
 
 	/*
-		ReduceInts reduces all ints in the list into a single int.
-		Elements of other types are ignored.
-		The function has two parameters: int returned by the previous iteration and current int. Returns int.
-		The list remains unchanged.
-
-		Parameters:
-		  - function - anonymous function to be executed.
+		IsSorted checks whether the elements of the list are in ascending order (see Compare).
 
 		Returns:
-		  - computed value.
+		  - true if the list is sorted, false otherwise.
 	*/
-	ReduceInts(initial int, function func(acc int, val int) int) int
+	IsSorted() bool
 
 	/*
-		ReduceFloats reduces all floats in the list into a single float.
-		Elements of other types are ignored.
-		The function has two parameters: float returned by the previous iteration and current float. Returns float.
-		The list remains unchanged.
+		BinarySearch searches a sorted list for a given element (see Compare).
+		The result is undefined if the list is not sorted.
 
 		Parameters:
-		  - function - anonymous function to be executed.
+		  - elem - the element to search for.
 
 		Returns:
-		  - computed value.
+		  - index of the element if found, otherwise (-(insertion point) - 1).
 	*/
-	ReduceFloats(initial float64, function func(acc float64, val float64) float64) float64
+	BinarySearch(elem any) int
+
+	/*
+		Reverse reverses the order of elements in the list.
+
+		Returns:
+		  - updated list.
+	*/
+	Reverse() List
+
+	/*
+		Shuffle randomly reorders the elements of the list.
+		Using a source with the same seed leads to the same order.
+
+		Parameters:
+		  - source - source of randomness (nil means the global source of the math/rand package).
+
+		Returns:
+		  - updated list.
+	*/
+	Shuffle(source *rand.Rand) List
+
+	/*
+		Sample creates a new list of randomly chosen elements of the list, without replacement.
+		Causes a panic if the size of the sample is lower than zero or higher than the count.
+
+		Parameters:
+		  - n - size of the sample,
+		  - source - source of randomness (nil means the global source of the math/rand package).
+
+		Returns:
+		  - new list.
+	*/
+	Sample(n int, source *rand.Rand) List
+
+	/*
+		WeightedSample creates a new list of randomly chosen objects of the list, without replacement.
+		Probability of choosing an object is proportional to its weight, specified by a given tree form.
+		Objects without a numeric weight or with zero weight are never chosen.
+		Causes a panic if any of the elements is not an object, if any of the weights is negative,
+		or if the size of the sample is higher than the number of objects with positive weight.
+
+		Parameters:
+		  - n - size of the sample,
+		  - tf - tree form of the weight,
+		  - source - source of randomness (nil means the global source of the math/rand package).
+
+		Returns:
+		  - new list.
+	*/
+	WeightedSample(n int, tf string, source *rand.Rand) List
+
+	/*
+		RandomElement acquires a randomly chosen element of the list.
+		Causes a panic if the list is empty.
+
+		Parameters:
+		  - source - source of randomness (nil means the global source of the math/rand package).
+
+		Returns:
+		  - the element (any type, has to be asserted).
+	*/
+	RandomElement(source *rand.Rand) any
+
+	/*
+		AllObjects checks if the list is homogeneous and all of its elements are objects.
+
+		Returns:
+		  - true if all elements are objects, false otherwise.
+	*/
+	AllObjects() bool
+
+	/*
+		AllLists checks if the list is homogeneous and all of its elements are lists.
+
+		Returns:
+		  - true if all elements are lists, false otherwise.
+	*/
+	AllLists() bool
+
+	/*
+		AllStrings checks if the list is homogeneous and all of its elements are strings.
+
+		Returns:
+		  - true if all elements are strings, false otherwise.
+	*/
+	AllStrings() bool
+
+	/*
+		AllBools checks if the list is homogeneous and all of its elements are bools.
+
+		Returns:
+		  - true if all elements are bools, false otherwise.
+	*/
+	AllBools() bool
+
+	/*
+		AllInts checks if the list is homogeneous and all of its elements are ints.
+
+		Returns:
+		  - true if all elements are ints, false otherwise.
+	*/
+	AllInts() bool
+
+	/*
+		AllFloats checks if the list is homogeneous and all of its elements are floats.
+
+		Returns:
+		  - true if all elements are floats, false otherwise.
+	*/
+	AllFloats() bool
+
+	/*
+		AllNumeric checks if all elements of the list are numeric (ints or floats).
+
+		Returns:
+		  - true if all elements are numeric, false otherwise.
+	*/
+	AllNumeric() bool
+
+	/*
+		ForEach executes a given function over an every element of the list.
+		The function has two parameters: index of the current element and its value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEach(function func(i int, val any)) List
+
+	/*
+		ForEachValue executes a given function over an every element of the list.
+		The function has one parameter, value of the current element.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachValue(function func(x any)) List
+
+	/*
+		ForEachObject executes a given function over all objects in the list.
+		Elements with other types are ignored.
+		The function has one parameter, the current object.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachObject(function func(x Object)) List
+
+	/*
+		ForEachList executes a given function over all lists nested in the list.
+		Elements with other types are ignored.
+		The function has one parameter, the current list.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachList(function func(x List)) List
+
+	/*
+		ForEachString executes a given function over all strings in the list.
+		Elements with other types are ignored.
+		The function has one parameter, the current string.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachString(function func(x string)) List
+
+	/*
+		ForEachBool executes a given function over all bools in the list.
+		Elements with other types are ignored.
+		The function has one parameter, the current bool.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachBool(function func(x bool)) List
+
+	/*
+		ForEachInt executes a given function over all ints in the list.
+		Elements with other types are ignored.
+		The function has one parameter, the current int.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachInt(function func(x int)) List
+
+	/*
+		ForEachFloat executes a given function over all floats in the list.
+		Elements with other types are ignored.
+		The function has one parameter, the current float.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEachFloat(function func(x float64)) List
+
+	/*
+		Map copies the list and modifies each element by a given mapping function.
+		The resulting element can have a different type than the original one.
+		The function has two parameters: current index and value of the current element. Returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	Map(function func(i int, val any) any) List
+
+	/*
+		MapValues copies the list and modifies each element by a given mapping function.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, value of the current element, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapValues(function func(x any) any) List
+
+	/*
+		MapObjects selects all objects from the list and modifies each of them by a given mapping function.
+		Elements of other types are ignored.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, the current object, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapObjects(function func(x Object) any) List
+
+	/*
+		MapLists selects all nested lists from the list and modifies each of them by a given mapping function.
+		Elements of other types are ignored.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, the current list, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapLists(function func(x List) any) List
+
+	/*
+		MapStrings selects all strings from the list and modifies each of them by a given mapping function.
+		Elements of other types are ignored.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, the current string, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapStrings(function func(x string) any) List
+
+	/*
+		MapBools selects all bools from the list and modifies each of them by a given mapping function.
+		Elements of other types are ignored.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, the current bool, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapBools(function func(x bool) any) List
+
+	/*
+		MapInts selects all ints from the list and modifies each of them by a given mapping function.
+		Elements of other types are ignored.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, the current int, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapInts(function func(x int) any) List
+
+	/*
+		MapFloats selects all floats from the list and modifies each of them by a given mapping function.
+		Elements of other types are ignored.
+		The resulting element can have a different type than the original one.
+		The function has one parameter, the current float, and returns any.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapFloats(function func(x float64) any) List
+
+	/*
+		Reduce reduces all elements of the list into a single value.
+		The function has two parameters: value returned by the previous iteration and value of the current element. Returns any.
+		The list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	Reduce(initial any, function func(acc any, val any) any) any
+
+	/*
+		ReduceStrings reduces all strings in the list into a single string.
+		Elements of other types are ignored.
+		The function has two parameters: string returned by the previous iteration and current string. Returns string.
+		The list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	ReduceStrings(initial string, function func(acc string, val string) string) string
+
+	/*
+		ReduceInts reduces all ints in the list into a single int.
+		Elements of other types are ignored.
+		The function has two parameters: int returned by the previous iteration and current int. Returns int.
+		The list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	ReduceInts(initial int, function func(acc int, val int) int) int
+
+	/*
+		ReduceFloats reduces all floats in the list into a single float.
+		Elements of other types are ignored.
+		The function has two parameters: float returned by the previous iteration and current float. Returns float.
+		The list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	ReduceFloats(initial float64, function func(acc float64, val float64) float64) float64
 
 	/*
 		Filter creates a new list containing elements of the old one satisfying a condition.
@@ -773,23 +1210,263 @@ type List interface {
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - filtered list.
+		  - filtered list.
+	*/
+	FilterInts(function func(x int) bool) List
+
+	/*
+		FilterFloats creates a new list containing floats of the old one satisfying a condition.
+		Elements of other types are ignored.
+		The function has one parameter, current float, and returns bool.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - filtered list.
+	*/
+	FilterFloats(function func(x float64) bool) List
+
+	/*
+		Flatten creates a new list with nested lists replaced by their elements.
+		The old list remains unchanged.
+
+		Parameters:
+		  - depth - number of nesting levels to flatten (negative value means all levels).
+
+		Returns:
+		  - new list.
+	*/
+	Flatten(depth int) List
+
+	/*
+		FlatMap maps each element of the list to a list by a given function and concatenates the results.
+		The function has one parameter, the current element, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMap(function func(x any) List) List
+
+	/*
+		FlatMapObjects selects all objects from the list, maps each of them to a list by a given function and concatenates the results.
+		Elements of other types are ignored.
+		The function has one parameter, the current object, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMapObjects(function func(x Object) List) List
+
+	/*
+		FlatMapLists selects all lists from the list, maps each of them to a list by a given function and concatenates the results.
+		Elements of other types are ignored.
+		The function has one parameter, the current list, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMapLists(function func(x List) List) List
+
+	/*
+		FlatMapStrings selects all strings from the list, maps each of them to a list by a given function and concatenates the results.
+		Elements of other types are ignored.
+		The function has one parameter, the current string, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMapStrings(function func(x string) List) List
+
+	/*
+		FlatMapBools selects all bools from the list, maps each of them to a list by a given function and concatenates the results.
+		Elements of other types are ignored.
+		The function has one parameter, the current bool, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMapBools(function func(x bool) List) List
+
+	/*
+		FlatMapInts selects all ints from the list, maps each of them to a list by a given function and concatenates the results.
+		Elements of other types are ignored.
+		The function has one parameter, the current int, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMapInts(function func(x int) List) List
+
+	/*
+		FlatMapFloats selects all floats from the list, maps each of them to a list by a given function and concatenates the results.
+		Elements of other types are ignored.
+		The function has one parameter, the current float, and returns a list.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	FlatMapFloats(function func(x float64) List) List
+
+	/*
+		Zip combines the list with other lists into a list of tuples (lists).
+		The i-th tuple contains the i-th elements of all the lists, the result has a count of the shortest list.
+
+		Parameters:
+		  - others... - lists to combine with.
+
+		Returns:
+		  - new list of lists.
+	*/
+	Zip(others ...List) List
+
+	/*
+		Interleave merges the list with other lists by alternately taking their elements.
+		When some of the lists run out of elements, the remaining ones continue.
+
+		Parameters:
+		  - others... - lists to merge with.
+
+		Returns:
+		  - new list.
+	*/
+	Interleave(others ...List) List
+
+	/*
+		Chunk splits the list into lists of a given size, the last one can be shorter.
+		Causes a panic if the size is lower than 1.
+
+		Parameters:
+		  - size - number of elements in a chunk.
+
+		Returns:
+		  - new list of lists.
+	*/
+	Chunk(size int) List
+
+	/*
+		Window creates sliding windows (lists) of a given size over the list.
+		Only complete windows are created.
+		Causes a panic if the size or the step is lower than 1.
+
+		Parameters:
+		  - size - number of elements in a window,
+		  - step - distance between starts of two subsequent windows.
+
+		Returns:
+		  - new list of lists.
+	*/
+	Window(size int, step int) List
+
+	/*
+		TakeWhile creates a new list containing the leading elements of the old one satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	TakeWhile(function func(x any) bool) List
+
+	/*
+		DropWhile creates a new list without the leading elements of the old one satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	DropWhile(function func(x any) bool) List
+
+	/*
+		Scan reduces the elements of the list like Reduce, but collects all intermediate results.
+		The function has two parameters: accumulator and the current element.
+
+		Parameters:
+		  - initial - initial value of the accumulator,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list of the accumulated values (without the initial one).
+	*/
+	Scan(initial any, function func(acc any, val any) any) List
+
+	/*
+		ScanStrings reduces all strings in the list like ReduceStrings, but collects all intermediate results.
+		Elements of other types are ignored.
+		The function has two parameters: accumulator and the current string.
+
+		Parameters:
+		  - initial - initial value of the accumulator,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list of the accumulated values (without the initial one).
+	*/
+	ScanStrings(initial string, function func(acc string, val string) string) List
+
+	/*
+		ScanInts reduces all ints in the list like ReduceInts, but collects all intermediate results.
+		Elements of other types are ignored.
+		The function has two parameters: accumulator and the current int.
+
+		Parameters:
+		  - initial - initial value of the accumulator,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list of the accumulated values (without the initial one).
 	*/
-	FilterInts(function func(x int) bool) List
+	ScanInts(initial int, function func(acc int, val int) int) List
 
 	/*
-		FilterFloats creates a new list containing floats of the old one satisfying a condition.
+		ScanFloats reduces all floats in the list like ReduceFloats, but collects all intermediate results.
 		Elements of other types are ignored.
-		The function has one parameter, current float, and returns bool.
-		The old list remains unchanged.
+		The function has two parameters: accumulator and the current float.
 
 		Parameters:
+		  - initial - initial value of the accumulator,
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - filtered list.
+		  - new list of the accumulated values (without the initial one).
 	*/
-	FilterFloats(function func(x float64) bool) List
+	ScanFloats(initial float64, function func(acc float64, val float64) float64) List
 
 	/*
 		IntSum computes a sum of all elements in the list.
@@ -828,11 +1505,11 @@ type List interface {
 	Prod() float64
 
 	/*
-		Avg computes an arithmetic mean of all elements in the list.
-		All elements of the list have to be numeric.
+		Avg computes an arithmetic mean of all numbers in the list.
+		Non-numeric elements are ignored.
 
 		Returns:
-		  - computed average value (float).
+		  - computed average value (float, 0 if there are no numbers).
 	*/
 	Avg() float64
 
@@ -846,9 +1523,10 @@ type List interface {
 
 	/*
 		Min finds a minimum number of the list.
+		Non-numeric elements are ignored.
 
 		Returns:
-		  - found minimum (float).
+		  - found minimum (float, 0 if there are no numbers).
 	*/
 	Min() float64
 
@@ -862,12 +1540,373 @@ type List interface {
 
 	/*
 		Max finds a maximum number of the list.
+		Non-numeric elements are ignored.
 
 		Returns:
-		  - found maximum (float).
+		  - found maximum (float, 0 if there are no numbers).
 	*/
 	Max() float64
 
+	/*
+		AvgOk is a variant of Avg distinguishing the case when there are no numbers.
+
+		Returns:
+		  - computed average value (float),
+		  - false if there are no numbers, true otherwise.
+	*/
+	AvgOk() (float64, bool)
+
+	/*
+		MinOk is a variant of Min distinguishing the case when there are no numbers.
+
+		Returns:
+		  - found minimum (float),
+		  - false if there are no numbers, true otherwise.
+	*/
+	MinOk() (float64, bool)
+
+	/*
+		MaxOk is a variant of Max distinguishing the case when there are no numbers.
+
+		Returns:
+		  - found maximum (float),
+		  - false if there are no numbers, true otherwise.
+	*/
+	MaxOk() (float64, bool)
+
+	/*
+		Median computes a median of all numbers in the list.
+		Non-numeric elements are ignored.
+
+		Returns:
+		  - computed median (float, 0 if there are not enough numbers).
+	*/
+	Median() float64
+
+	/*
+		MedianOk is a variant of Median distinguishing the case when there are not enough numbers.
+
+		Returns:
+		  - computed median (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	MedianOk() (float64, bool)
+
+	/*
+		Mode finds the most frequent number of the list.
+		Non-numeric elements are ignored.
+		If more numbers have the same frequency, the lowest one is returned.
+
+		Returns:
+		  - found mode (float, 0 if there are not enough numbers).
+	*/
+	Mode() float64
+
+	/*
+		ModeOk is a variant of Mode distinguishing the case when there are not enough numbers.
+
+		Returns:
+		  - found mode (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	ModeOk() (float64, bool)
+
+	/*
+		Percentile computes a given percentile of all numbers in the list.
+		Non-numeric elements are ignored.
+		Values between the closest ranks are linearly interpolated.
+		Causes a panic if the percentile is out of range.
+
+		Parameters:
+		  - p - the percentile, from 0 to 100.
+
+		Returns:
+		  - computed percentile (float, 0 if there are not enough numbers).
+	*/
+	Percentile(p float64) float64
+
+	/*
+		PercentileOk is a variant of Percentile distinguishing the case when there are not enough numbers.
+
+		Parameters:
+		  - p - the percentile, from 0 to 100.
+
+		Returns:
+		  - computed percentile (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	PercentileOk(p float64) (float64, bool)
+
+	/*
+		Variance computes a population variance of all numbers in the list.
+		Non-numeric elements are ignored.
+
+		Returns:
+		  - computed variance (float, 0 if there are not enough numbers).
+	*/
+	Variance() float64
+
+	/*
+		VarianceOk is a variant of Variance distinguishing the case when there are not enough numbers.
+
+		Returns:
+		  - computed variance (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	VarianceOk() (float64, bool)
+
+	/*
+		SampleVariance computes a sample variance (with Bessel's correction) of all numbers in the list.
+		Non-numeric elements are ignored.
+		At least two numbers are needed.
+
+		Returns:
+		  - computed variance (float, 0 if there are not enough numbers).
+	*/
+	SampleVariance() float64
+
+	/*
+		SampleVarianceOk is a variant of SampleVariance distinguishing the case when there are not enough numbers.
+
+		Returns:
+		  - computed variance (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	SampleVarianceOk() (float64, bool)
+
+	/*
+		StdDev computes a population standard deviation of all numbers in the list.
+		Non-numeric elements are ignored.
+
+		Returns:
+		  - computed standard deviation (float, 0 if there are not enough numbers).
+	*/
+	StdDev() float64
+
+	/*
+		StdDevOk is a variant of StdDev distinguishing the case when there are not enough numbers.
+
+		Returns:
+		  - computed standard deviation (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	StdDevOk() (float64, bool)
+
+	/*
+		SampleStdDev computes a sample standard deviation (with Bessel's correction) of all numbers in the list.
+		Non-numeric elements are ignored.
+		At least two numbers are needed.
+
+		Returns:
+		  - computed standard deviation (float, 0 if there are not enough numbers).
+	*/
+	SampleStdDev() float64
+
+	/*
+		SampleStdDevOk is a variant of SampleStdDev distinguishing the case when there are not enough numbers.
+
+		Returns:
+		  - computed standard deviation (float),
+		  - false if there are not enough numbers, true otherwise.
+	*/
+	SampleStdDevOk() (float64, bool)
+
+	/*
+		Histogram splits the range of the numbers in the list into bins of the same width and counts the numbers in them.
+		The last bin includes its upper bound. Non-numeric elements are ignored.
+		Causes a panic if the number of bins is lower than 1.
+
+		Parameters:
+		  - bins - number of bins.
+
+		Returns:
+		  - list of objects with keys "min", "max" (floats, bounds of the bin) and "count" (int).
+	*/
+	Histogram(bins int) List
+
+	/*
+		CumSum computes cumulative sums of the numbers in the list.
+		Non-numeric elements are ignored.
+
+		Returns:
+		  - new list of the sums (floats).
+	*/
+	CumSum() List
+
+	/*
+		Normalize scales the numbers in the list linearly to the range from 0 to 1 (minimum to 0, maximum to 1).
+		If all the numbers are equal, they are scaled to 0. Non-numeric elements are ignored.
+
+		Returns:
+		  - new list of the scaled numbers (floats).
+	*/
+	Normalize() List
+
+	/*
+		ArgMin gives a position of the minimum number of the list (the first one if there are more).
+		Non-numeric elements are ignored.
+
+		Returns:
+		  - index of the minimum (-1 if there are no numbers).
+	*/
+	ArgMin() int
+
+	/*
+		ArgMax gives a position of the maximum number of the list (the first one if there are more).
+		Non-numeric elements are ignored.
+
+		Returns:
+		  - index of the maximum (-1 if there are no numbers).
+	*/
+	ArgMax() int
+
+	/*
+		GroupBy splits a list of objects into groups by a value specified by a given tree form.
+		Non-string values are converted to keys by their JSON serialization.
+		Objects not containing the value are omitted.
+		Causes a panic if any of the elements is not an object or if values of different types are converted
+		to the same key (e.g. 1 and "1", nil and "null"), ints and floats are considered the same type.
+
+		Parameters:
+		  - tf - tree form of the grouping value.
+
+		Returns:
+		  - object mapping the keys to lists of objects (in the original order).
+	*/
+	GroupBy(tf string) Object
+
+	/*
+		Partition splits the list into two new lists by a condition.
+		The function has one parameter, current element, and returns bool.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - list of elements satisfying the condition,
+		  - list of the other elements.
+	*/
+	Partition(function func(x any) bool) (List, List)
+
+	/*
+		CountBy counts objects of the list by a value specified by a given tree form.
+		Keys are created the same way as in GroupBy, objects not containing the value are omitted.
+		Causes a panic if any of the elements is not an object or if values of different types are converted to the same key.
+
+		Parameters:
+		  - tf - tree form of the counted value.
+
+		Returns:
+		  - object mapping the keys to the counts (ints).
+	*/
+	CountBy(tf string) Object
+
+	/*
+		IndexBy creates an object of the list's objects indexed by a value specified by a given tree form.
+		Keys are created the same way as in GroupBy, objects not containing the value are omitted.
+		If more objects have the same key, the last one is used.
+		Causes a panic if any of the elements is not an object or if values of different types are converted to the same key.
+
+		Parameters:
+		  - tf - tree form of the key.
+
+		Returns:
+		  - object mapping the keys to the objects.
+	*/
+	IndexBy(tf string) Object
+
+	/*
+		SumBy computes a sum of values specified by a given tree form in all objects of the list.
+		Objects not containing the value and non-numeric values are ignored.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the summed value.
+
+		Returns:
+		  - computed sum (float).
+	*/
+	SumBy(tf string) float64
+
+	/*
+		AvgBy computes an arithmetic mean of values specified by a given tree form in all objects of the list.
+		Objects not containing the value and non-numeric values are ignored.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the averaged value.
+
+		Returns:
+		  - computed average value (float).
+	*/
+	AvgBy(tf string) float64
+
+	/*
+		MinBy finds a minimum of values specified by a given tree form in all objects of the list.
+		Objects not containing the value and non-numeric values are ignored.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the compared value.
+
+		Returns:
+		  - found minimum (float).
+	*/
+	MinBy(tf string) float64
+
+	/*
+		MaxBy finds a maximum of values specified by a given tree form in all objects of the list.
+		Objects not containing the value and non-numeric values are ignored.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the compared value.
+
+		Returns:
+		  - found maximum (float).
+	*/
+	MaxBy(tf string) float64
+
+	/*
+		AvgByOk is a variant of AvgBy distinguishing the case when there are no numeric values.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the averaged value.
+
+		Returns:
+		  - computed average value (float),
+		  - false if there are no numeric values, true otherwise.
+	*/
+	AvgByOk(tf string) (float64, bool)
+
+	/*
+		MinByOk is a variant of MinBy distinguishing the case when there are no numeric values.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the compared value.
+
+		Returns:
+		  - found minimum (float),
+		  - false if there are no numeric values, true otherwise.
+	*/
+	MinByOk(tf string) (float64, bool)
+
+	/*
+		MaxByOk is a variant of MaxBy distinguishing the case when there are no numeric values.
+		Causes a panic if any of the elements is not an object.
+
+		Parameters:
+		  - tf - tree form of the compared value.
+
+		Returns:
+		  - found maximum (float),
+		  - false if there are no numeric values, true otherwise.
+	*/
+	MaxByOk(tf string) (float64, bool)
+
 	/*
 		ForEachAsync parallelly executes a given function over an every element of the list.
 		The function has two parameters: index of the current element and its value.
@@ -942,4 +1981,72 @@ type List interface {
 		  - integer constant representing the type (see type enum).
 	*/
 	TypeOfTF(tf string) Type
+
+	/*
+		Walk recursively visits all nested values of the list (depth-first, parents before their children).
+		Keys of objects are visited in alphabetical order.
+		The function has three parameters: tree form path of the current value (relative to the list), the value and its parent,
+		and returns WalkContinue, WalkSkip (children of the value are not visited) or WalkStop (the traversal ends).
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	Walk(function func(path string, value any, parent any) WalkAction) List
+
+	/*
+		WalkType recursively visits all nested values of a given type.
+		Values of other types are traversed, but not passed to the function.
+		The function has two parameters: tree form path of the current value and the value, and returns WalkAction (see Walk).
+
+		Parameters:
+		  - t - type of the visited values,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	WalkType(t Type, function func(path string, value any) WalkAction) List
+
+	/*
+		Transform creates a new list by recursively applying a function to all nested values (parents before their children).
+		The function has two parameters: tree form path of the current value and the value, and returns the new value.
+		If the new value is an object or a list, its children are transformed as well.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	Transform(function func(path string, value any) any) List
+
+	/*
+		TransformType creates a new list by recursively applying a function to all nested values of a given type.
+		Values of other types are kept (their children are transformed).
+		The old list remains unchanged.
+
+		Parameters:
+		  - t - type of the transformed values,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	TransformType(t Type, function func(path string, value any) any) List
+
+	/*
+		FlattenWith creates a single-level object mapping paths of all leaves of the list to their values.
+		Empty nested objects and lists are considered leaves. The original structure can be rebuilt by UnflattenList.
+
+		Parameters:
+		  - options - flattening options.
+
+		Returns:
+		  - new object.
+	*/
+	FlattenWith(options FlattenOptions) Object
 }
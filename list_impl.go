@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"math/bits"
+	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
@@ -181,7 +182,10 @@ Returns:
 */
 func (ego *list) isEqual(another any) bool {
 	list, ok := another.(*list)
-	if !ok || ego.Ego().Count() != list.Count() {
+	if !ok {
+		return ego.isEqualDerived(another)
+	}
+	if ego.Ego().Count() != list.Count() {
 		return false
 	}
 	for i := range ego.val {
@@ -192,6 +196,29 @@ func (ego *list) isEqual(another any) bool {
 	return true
 }
 
+/*
+Checks if the content of the list is equal to a derived structure embedding a list.
+The elements of the derived structure are accessed through the List interface.
+
+Parameters:
+  - another - value to compare with.
+
+Returns:
+  - true if the contents are equal, false otherwise.
+*/
+func (ego *list) isEqualDerived(another any) bool {
+	list, ok := another.(List)
+	if !ok || ego.Ego().Count() != list.Count() {
+		return false
+	}
+	for i := range ego.val {
+		if !ego.val[i].isEqual(parseVal(list.Get(i))) {
+			return false
+		}
+	}
+	return true
+}
+
 func (ego *list) Init(ptr List) {
 	ego.ptr = ptr
 }
@@ -229,6 +256,7 @@ func (ego *list) Replace(index int, value any) List {
 
 func (ego *list) Delete(indexes ...int) List {
 	if len(indexes) > 1 {
+		indexes = append([]int{}, indexes...)
 		sort.Ints(indexes)
 	}
 	for i := len(indexes) - 1; i >= 0; i-- {
@@ -245,6 +273,117 @@ func (ego *list) Pop() List {
 	return ego.Ego().Delete(ego.Ego().Count() - 1)
 }
 
+/*
+Converts a possibly negative index to a position in the list and checks its range.
+Causes a panic if the index is out of range.
+
+Parameters:
+  - index - the index, negative index counts from the end of the list,
+  - limit - upper bound of the position (exclusive).
+
+Returns:
+  - position in the list.
+*/
+func (ego *list) resolveIndex(index int, limit int) int {
+	resolved := index
+	if resolved < 0 {
+		resolved += ego.Ego().Count()
+	}
+	if resolved < 0 || resolved >= limit {
+		panic(fmt.Sprintf("index %d out of range with count %d", index, ego.Ego().Count()))
+	}
+	return resolved
+}
+
+func (ego *list) Shift() List {
+	return ego.Ego().Delete(0)
+}
+
+func (ego *list) Unshift(values ...any) List {
+	return ego.Ego().InsertAll(0, values...)
+}
+
+func (ego *list) InsertAll(index int, values ...any) List {
+	index = ego.resolveIndex(index, ego.Ego().Count()+1)
+	for i, value := range values {
+		ego.Ego().Insert(index+i, value)
+	}
+	return ego.Ego()
+}
+
+func (ego *list) Splice(start int, deleteCount int, items ...any) List {
+	count := ego.Ego().Count()
+	start = ego.resolveIndex(start, count+1)
+	if deleteCount < 0 {
+		panic("number of deleted elements is lower than zero")
+	}
+	if start+deleteCount > count {
+		deleteCount = count - start
+	}
+	removed := NewList()
+	indexes := make([]int, deleteCount)
+	for i := range indexes {
+		indexes[i] = start + i
+		removed.Add(ego.Ego().Get(start + i))
+	}
+	if deleteCount > 0 {
+		ego.Ego().Delete(indexes...)
+	}
+	ego.Ego().InsertAll(start, items...)
+	return removed
+}
+
+func (ego *list) Swap(i int, j int) List {
+	i = ego.resolveIndex(i, ego.Ego().Count())
+	j = ego.resolveIndex(j, ego.Ego().Count())
+	ego.val[i], ego.val[j] = ego.val[j], ego.val[i]
+	return ego.Ego()
+}
+
+func (ego *list) Move(from int, to int) List {
+	from = ego.resolveIndex(from, ego.Ego().Count())
+	to = ego.resolveIndex(to, ego.Ego().Count())
+	item := ego.val[from]
+	if from < to {
+		copy(ego.val[from:to], ego.val[from+1:to+1])
+	} else {
+		copy(ego.val[to+1:from+1], ego.val[to:from])
+	}
+	ego.val[to] = item
+	return ego.Ego()
+}
+
+func (ego *list) Rotate(k int) List {
+	count := len(ego.val)
+	if count == 0 {
+		return ego.Ego()
+	}
+	k %= count
+	if k < 0 {
+		k += count
+	}
+	ego.val = append(ego.val[count-k:], ego.val[:count-k]...)
+	return ego.Ego()
+}
+
+func (ego *list) Fill(value any, start int, end int) List {
+	count := ego.Ego().Count()
+	if end > count || end < -count {
+		panic(fmt.Sprintf("ending index %d out of range with count %d", end, count))
+	}
+	if end <= 0 {
+		end = count + end
+	}
+	start = ego.resolveIndex(start, count+1)
+	if start > end {
+		panic("starting index is higher than the ending index")
+	}
+	for i := start; i < end; i++ {
+		ego.Ego().Replace(i, parseVal(value).copy())
+	}
+	return ego.Ego()
+}
+
 func (ego *list) Clear() List {
 	ego.val = []field{}
 	return ego.Ego()
@@ -435,9 +574,10 @@ func (ego *list) Equals(another List) bool {
 }
 
 func (ego *list) Concat(another List) List {
-	newList := &list{val: append(ego.val, another.getVal().(*list).val...)}
+	newList := &list{val: make([]field, len(ego.val), len(ego.val)+another.Count())}
 	newList.Init(newList)
-	return newList
+	copy(newList.val, ego.val)
+	return newList.Add(another.Slice()...)
 }
 
 func (ego *list) SubList(start int, end int) List {
@@ -477,26 +617,263 @@ func (ego *list) IndexOf(elem any) int {
 	return -1
 }
 
+func (ego *list) LastIndexOf(elem any) int {
+	for i := len(ego.val) - 1; i >= 0; i-- {
+		if ego.val[i].getVal() == elem {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ego *list) Find(function func(any) bool) (any, bool) {
+	if i := ego.Ego().FindIndex(function); i >= 0 {
+		return ego.val[i].getVal(), true
+	}
+	return nil, false
+}
+
+func (ego *list) FindIndex(function func(any) bool) int {
+	for i, item := range ego.val {
+		if function(item.getVal()) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ego *list) FindLast(function func(any) bool) (any, bool) {
+	for i := len(ego.val) - 1; i >= 0; i-- {
+		if function(ego.val[i].getVal()) {
+			return ego.val[i].getVal(), true
+		}
+	}
+	return nil, false
+}
+
+func (ego *list) Any(function func(any) bool) bool {
+	return ego.Ego().FindIndex(function) >= 0
+}
+
+func (ego *list) All(function func(any) bool) bool {
+	return !ego.Ego().Any(func(x any) bool {
+		return !function(x)
+	})
+}
+
+func (ego *list) None(function func(any) bool) bool {
+	return !ego.Ego().Any(function)
+}
+
+func (ego *list) CountIf(function func(any) bool) (count int) {
+	for _, item := range ego.val {
+		if function(item.getVal()) {
+			count++
+		}
+	}
+	return
+}
+
+func (ego *list) RemoveIf(function func(any) bool) List {
+	indexes := []int{}
+	for i, item := range ego.val {
+		if function(item.getVal()) {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) > 0 {
+		ego.Ego().Delete(indexes...)
+	}
+	return ego.Ego()
+}
+
+func (ego *list) RetainIf(function func(any) bool) List {
+	return ego.Ego().RemoveIf(func(x any) bool {
+		return !function(x)
+	})
+}
+
+func (ego *list) ContainsDeep(elem any) bool {
+	return ego.Ego().IndexOfDeep(elem) >= 0
+}
+
+func (ego *list) IndexOfDeep(elem any) int {
+	value := parseVal(elem)
+	for i, item := range ego.val {
+		if item.isEqual(value) {
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+Collects fields of the given lists into a new list, skipping duplicates and fields not accepted by a filter.
+
+Parameters:
+  - filter - function deciding whether the field should be added,
+  - lists... - lists to collect.
+
+Returns:
+  - new list.
+*/
+func collectDistinct(filter func(field) bool, lists ...List) List {
+	result := &list{val: []field{}}
+	result.Init(result)
+	seen := newFieldSet(0)
+	for _, l := range lists {
+		l.ForEachValue(func(x any) {
+			item := parseVal(x)
+			if filter(item) && seen.add(item) {
+				result.val = append(result.val, item)
+			}
+		})
+	}
+	return result
+}
+
+/*
+Creates a set of all elements of a list.
+
+Parameters:
+  - l - list to convert.
+
+Returns:
+  - created set.
+*/
+func setOf(l List) *fieldSet {
+	set := newFieldSet(l.Count())
+	l.ForEachValue(func(x any) {
+		set.add(parseVal(x))
+	})
+	return set
+}
+
+func (ego *list) Distinct() List {
+	return collectDistinct(func(field) bool { return true }, ego.Ego())
+}
+
+func (ego *list) DistinctBy(tf string) List {
+	result := NewList()
+	seen := newFieldSet(0)
+	missing := false
+	for i := range ego.val {
+		obj := ego.Ego().GetObject(i)
+		if obj.TypeOfTF(tf) == TypeUndefined {
+			if !missing {
+				missing = true
+				result.Add(obj)
+			}
+		} else if seen.add(parseVal(obj.GetTF(tf))) {
+			result.Add(obj)
+		}
+	}
+	return result
+}
+
+func (ego *list) Union(another List) List {
+	return collectDistinct(func(field) bool { return true }, ego.Ego(), another)
+}
+
+func (ego *list) Intersect(another List) List {
+	other := setOf(another)
+	return collectDistinct(other.contains, ego.Ego())
+}
+
+func (ego *list) Difference(another List) List {
+	other := setOf(another)
+	return collectDistinct(func(item field) bool { return !other.contains(item) }, ego.Ego())
+}
+
+func (ego *list) SymmetricDifference(another List) List {
+	own, other := setOf(ego.Ego()), setOf(another)
+	return collectDistinct(func(item field) bool { return !own.contains(item) || !other.contains(item) }, ego.Ego(), another)
+}
+
 func (ego *list) Sort() List {
-	switch ego.val[0].(type) {
-	case *atString:
-		slice := ego.StringSlice()
-		sort.Strings(slice)
-		ego.val = NewListFrom(slice).(*list).val
-	case *atInt:
-		slice := ego.IntSlice()
-		sort.Ints(slice)
-		ego.val = NewListFrom(slice).(*list).val
-	case *atFloat:
-		slice := ego.FloatSlice()
-		sort.Float64s(slice)
-		ego.val = NewListFrom(slice).(*list).val
-	default:
-		panic("the first element of the list has to be either string, int or float")
+	sort.SliceStable(ego.val, func(i, j int) bool {
+		return compareValues(ego.val[i].getVal(), ego.val[j].getVal()) < 0
+	})
+	return ego.Ego()
+}
+
+func (ego *list) SortFunc(less func(any, any) bool) List {
+	sort.Slice(ego.val, func(i, j int) bool {
+		return less(ego.val[i].getVal(), ego.val[j].getVal())
+	})
+	return ego.Ego()
+}
+
+func (ego *list) SortStable(less func(any, any) bool) List {
+	sort.SliceStable(ego.val, func(i, j int) bool {
+		return less(ego.val[i].getVal(), ego.val[j].getVal())
+	})
+	return ego.Ego()
+}
+
+func (ego *list) SortBy(tfs ...string) List {
+	type sortKey struct {
+		tf         string
+		descending bool
+	}
+	keys := make([]sortKey, len(tfs))
+	for i, tf := range tfs {
+		keys[i] = sortKey{tf: strings.TrimPrefix(strings.TrimPrefix(tf, "+"), "-"), descending: strings.HasPrefix(tf, "-")}
+	}
+	type sortRow struct {
+		item   field
+		values []any
+	}
+	rows := make([]sortRow, len(ego.val))
+	for i, item := range ego.val {
+		obj := ego.Ego().GetObject(i)
+		rows[i] = sortRow{item: item, values: make([]any, len(keys))}
+		for j, key := range keys {
+			if obj.TypeOfTF(key.tf) == TypeUndefined {
+				rows[i].values[j] = undefined{}
+			} else {
+				rows[i].values[j] = obj.GetTF(key.tf)
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			c := compareDefined(rows[i].values[k], rows[j].values[k])
+			if key.descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	for i, row := range rows {
+		ego.val[i] = row.item
 	}
 	return ego.Ego()
 }
 
+func (ego *list) IsSorted() bool {
+	for i := 1; i < len(ego.val); i++ {
+		if compareValues(ego.val[i-1].getVal(), ego.val[i].getVal()) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (ego *list) BinarySearch(elem any) int {
+	value := parseVal(elem).getVal()
+	index := sort.Search(len(ego.val), func(i int) bool {
+		return compareValues(ego.val[i].getVal(), value) >= 0
+	})
+	if index < len(ego.val) && compareValues(ego.val[index].getVal(), value) == 0 {
+		return index
+	}
+	return -index - 1
+}
+
 func (ego *list) Reverse() List {
 	for i := ego.Ego().Count()/2 - 1; i >= 0; i-- {
 		opp := ego.Ego().Count() - 1 - i
@@ -505,6 +882,106 @@ func (ego *list) Reverse() List {
 	return ego.Ego()
 }
 
+/*
+Generates a random int from the interval [0, n).
+
+Parameters:
+  - source - source of randomness (nil means the global source),
+  - n - upper bound of the interval.
+
+Returns:
+  - generated number.
+*/
+func randomInt(source *rand.Rand, n int) int {
+	if source == nil {
+		return rand.Intn(n)
+	}
+	return source.Intn(n)
+}
+
+/*
+Generates a random float from the interval [0, 1).
+
+Parameters:
+  - source - source of randomness (nil means the global source).
+
+Returns:
+  - generated number.
+*/
+func randomFloat(source *rand.Rand) float64 {
+	if source == nil {
+		return rand.Float64()
+	}
+	return source.Float64()
+}
+
+func (ego *list) Shuffle(source *rand.Rand) List {
+	for i := len(ego.val) - 1; i > 0; i-- {
+		j := randomInt(source, i+1)
+		ego.val[i], ego.val[j] = ego.val[j], ego.val[i]
+	}
+	return ego.Ego()
+}
+
+func (ego *list) Sample(n int, source *rand.Rand) List {
+	if n < 0 || n > ego.Ego().Count() {
+		panic(fmt.Sprintf("sample size %d out of range with count %d", n, ego.Ego().Count()))
+	}
+	indexes := make([]int, len(ego.val))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	result := NewList()
+	for i := 0; i < n; i++ {
+		j := i + randomInt(source, len(indexes)-i)
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+		result.Add(ego.val[indexes[i]].getVal())
+	}
+	return result
+}
+
+func (ego *list) WeightedSample(n int, tf string, source *rand.Rand) List {
+	type candidate struct {
+		obj Object
+		key float64
+	}
+	candidates := []candidate{}
+	for i := range ego.val {
+		obj := ego.Ego().GetObject(i)
+		var weight float64
+		switch obj.TypeOfTF(tf) {
+		case TypeInt:
+			weight = float64(obj.GetTF(tf).(int))
+		case TypeFloat:
+			weight = obj.GetTF(tf).(float64)
+		}
+		if weight < 0 {
+			panic(fmt.Sprintf("weight %v is lower than zero", weight))
+		}
+		if weight > 0 {
+			candidates = append(candidates, candidate{obj, math.Pow(randomFloat(source), 1/weight)})
+		}
+	}
+	if n < 0 || n > len(candidates) {
+		panic(fmt.Sprintf("sample size %d out of range with %d weighted objects", n, len(candidates)))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].key > candidates[j].key
+	})
+	result := NewList()
+	for _, c := range candidates[:n] {
+		result.Add(c.obj)
+	}
+	return result
+}
+
+func (ego *list) RandomElement(source *rand.Rand) any {
+	if ego.Ego().Empty() {
+		panic("list is empty")
+	}
+	return ego.Ego().Get(randomInt(source, ego.Ego().Count()))
+}
+
 func (ego *list) AllObjects() bool {
 	for _, item := range ego.val {
 		_, ok := item.(Object)
@@ -840,18 +1317,255 @@ func (ego *list) FilterFloats(function func(float64) bool) List {
 	return result
 }
 
-func (ego *list) IntSum() (result int) {
-	for _, item := range ego.val {
-		value, ok := item.getVal().(int)
-		if ok {
-			result += value
-		}
-	}
-	return
-}
+/*
+Appends elements of a list to a result, flattening nested lists.
 
-func (ego *list) Sum() (result float64) {
-	for _, item := range ego.val {
+Parameters:
+  - result - list to append to,
+  - source - list to flatten,
+  - depth - number of levels to flatten (negative means all).
+*/
+func flattenInto(result List, source List, depth int) {
+	source.ForEachValue(func(x any) {
+		if nested, ok := x.(List); ok && depth != 0 {
+			flattenInto(result, nested, depth-1)
+		} else {
+			result.Add(x)
+		}
+	})
+}
+
+func (ego *list) Flatten(depth int) List {
+	result := NewList()
+	flattenInto(result, ego.Ego(), depth)
+	return result
+}
+
+func (ego *list) FlatMap(function func(any) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		result.Add(function(item.getVal()).Slice()...)
+	}
+	return result
+}
+
+func (ego *list) FlatMapObjects(function func(Object) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		val, ok := item.(Object)
+		if ok {
+			result.Add(function(val).Slice()...)
+		}
+	}
+	return result
+}
+
+func (ego *list) FlatMapLists(function func(List) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		val, ok := item.(List)
+		if ok {
+			result.Add(function(val).Slice()...)
+		}
+	}
+	return result
+}
+
+func (ego *list) FlatMapStrings(function func(string) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		val, ok := item.getVal().(string)
+		if ok {
+			result.Add(function(val).Slice()...)
+		}
+	}
+	return result
+}
+
+func (ego *list) FlatMapBools(function func(bool) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		val, ok := item.getVal().(bool)
+		if ok {
+			result.Add(function(val).Slice()...)
+		}
+	}
+	return result
+}
+
+func (ego *list) FlatMapInts(function func(int) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		val, ok := item.getVal().(int)
+		if ok {
+			result.Add(function(val).Slice()...)
+		}
+	}
+	return result
+}
+
+func (ego *list) FlatMapFloats(function func(float64) List) List {
+	result := NewList()
+	for _, item := range ego.val {
+		val, ok := item.getVal().(float64)
+		if ok {
+			result.Add(function(val).Slice()...)
+		}
+	}
+	return result
+}
+
+func (ego *list) Zip(others ...List) List {
+	lists := append([]List{ego.Ego()}, others...)
+	count := ego.Ego().Count()
+	for _, l := range others {
+		if l.Count() < count {
+			count = l.Count()
+		}
+	}
+	result := NewList()
+	for i := 0; i < count; i++ {
+		tuple := NewList()
+		for _, l := range lists {
+			tuple.Add(l.Get(i))
+		}
+		result.Add(tuple)
+	}
+	return result
+}
+
+func (ego *list) Interleave(others ...List) List {
+	lists := append([]List{ego.Ego()}, others...)
+	result := NewList()
+	for i := 0; ; i++ {
+		added := false
+		for _, l := range lists {
+			if i < l.Count() {
+				result.Add(l.Get(i))
+				added = true
+			}
+		}
+		if !added {
+			return result
+		}
+	}
+}
+
+func (ego *list) Chunk(size int) List {
+	if size < 1 {
+		panic("chunk size is lower than one")
+	}
+	result := NewList()
+	count := ego.Ego().Count()
+	for start := 0; start < count; start += size {
+		end := start + size
+		if end > count {
+			end = count
+		}
+		result.Add(ego.Ego().SubList(start, end))
+	}
+	return result
+}
+
+func (ego *list) Window(size int, step int) List {
+	if size < 1 {
+		panic("window size is lower than one")
+	}
+	if step < 1 {
+		panic("window step is lower than one")
+	}
+	result := NewList()
+	for start := 0; start+size <= ego.Ego().Count(); start += step {
+		result.Add(ego.Ego().SubList(start, start+size))
+	}
+	return result
+}
+
+func (ego *list) TakeWhile(function func(any) bool) List {
+	result := NewList()
+	for _, item := range ego.val {
+		if !function(item.getVal()) {
+			break
+		}
+		result.Add(item.getVal())
+	}
+	return result
+}
+
+func (ego *list) DropWhile(function func(any) bool) List {
+	result := NewList()
+	dropping := true
+	for _, item := range ego.val {
+		if dropping && function(item.getVal()) {
+			continue
+		}
+		dropping = false
+		result.Add(item.getVal())
+	}
+	return result
+}
+
+func (ego *list) Scan(initial any, function func(any, any) any) List {
+	result := NewList()
+	acc := initial
+	for _, item := range ego.val {
+		acc = function(acc, item.getVal())
+		result.Add(acc)
+	}
+	return result
+}
+
+func (ego *list) ScanStrings(initial string, function func(string, string) string) List {
+	result := NewList()
+	acc := initial
+	for _, item := range ego.val {
+		val, ok := item.getVal().(string)
+		if ok {
+			acc = function(acc, val)
+			result.Add(acc)
+		}
+	}
+	return result
+}
+
+func (ego *list) ScanInts(initial int, function func(int, int) int) List {
+	result := NewList()
+	acc := initial
+	for _, item := range ego.val {
+		val, ok := item.getVal().(int)
+		if ok {
+			acc = function(acc, val)
+			result.Add(acc)
+		}
+	}
+	return result
+}
+
+func (ego *list) ScanFloats(initial float64, function func(float64, float64) float64) List {
+	result := NewList()
+	acc := initial
+	for _, item := range ego.val {
+		val, ok := item.getVal().(float64)
+		if ok {
+			acc = function(acc, val)
+			result.Add(acc)
+		}
+	}
+	return result
+}
+
+func (ego *list) IntSum() (result int) {
+	for _, item := range ego.val {
+		value, ok := item.getVal().(int)
+		if ok {
+			result += value
+		}
+	}
+	return
+}
+
+func (ego *list) Sum() (result float64) {
+	for _, item := range ego.val {
 		val, ok := item.getVal().(int)
 		if ok {
 			result += float64(val)
@@ -887,7 +1601,8 @@ func (ego *list) Prod() (result float64) {
 }
 
 func (ego *list) Avg() float64 {
-	return ego.Ego().Sum() / float64(ego.Ego().Count())
+	avg, _ := ego.Ego().AvgOk()
+	return avg
 }
 
 func (ego *list) IntMin() int {
@@ -908,29 +1623,8 @@ func (ego *list) IntMin() int {
 }
 
 func (ego *list) Min() float64 {
-	var present bool
-	min := ego.Ego().Reduce(math.MaxFloat64, func(min any, item any) any {
-		present = true
-		val, ok := item.(int)
-		if ok {
-			if float64(val) < min.(float64) {
-				return float64(val)
-			} else {
-				return min
-			}
-		} else {
-			if item.(float64) < min.(float64) {
-				return item
-			} else {
-				return min
-			}
-		}
-	}).(float64)
-	if present {
-		return min
-	} else {
-		return 0
-	}
+	min, _ := ego.Ego().MinOk()
+	return min
 }
 
 func (ego *list) IntMax() int {
@@ -951,29 +1645,303 @@ func (ego *list) IntMax() int {
 }
 
 func (ego *list) Max() float64 {
-	var present bool
-	max := ego.Ego().Reduce(-math.MaxFloat64, func(max any, item any) any {
-		present = true
-		val, ok := item.(int)
-		if ok {
-			if float64(val) > max.(float64) {
-				return float64(val)
-			} else {
-				return max
+	max, _ := ego.Ego().MaxOk()
+	return max
+}
+
+/*
+Collects all numbers of the list, ints are converted to floats.
+
+Returns:
+  - slice of the numbers.
+*/
+func (ego *list) numbers() []float64 {
+	result := make([]float64, 0, len(ego.val))
+	for _, item := range ego.val {
+		switch val := item.getVal().(type) {
+		case int:
+			result = append(result, float64(val))
+		case float64:
+			result = append(result, val)
+		}
+	}
+	return result
+}
+
+/*
+Collects all numbers of the list in ascending order.
+
+Returns:
+  - sorted slice of the numbers.
+*/
+func (ego *list) sortedNumbers() []float64 {
+	result := ego.numbers()
+	sort.Float64s(result)
+	return result
+}
+
+func (ego *list) AvgOk() (float64, bool) {
+	numbers := ego.numbers()
+	if len(numbers) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, val := range numbers {
+		sum += val
+	}
+	return sum / float64(len(numbers)), true
+}
+
+func (ego *list) MinOk() (float64, bool) {
+	i, min := ego.argBest(isLower)
+	return min, i >= 0
+}
+
+func (ego *list) MaxOk() (float64, bool) {
+	i, max := ego.argBest(isHigher)
+	return max, i >= 0
+}
+
+func (ego *list) Median() float64 {
+	median, _ := ego.Ego().MedianOk()
+	return median
+}
+
+func (ego *list) MedianOk() (float64, bool) {
+	return ego.Ego().PercentileOk(50)
+}
+
+func (ego *list) Mode() float64 {
+	mode, _ := ego.Ego().ModeOk()
+	return mode
+}
+
+func (ego *list) ModeOk() (float64, bool) {
+	numbers := ego.sortedNumbers()
+	if len(numbers) == 0 {
+		return 0, false
+	}
+	mode, best := numbers[0], 0
+	for i := 0; i < len(numbers); {
+		j := i
+		for j < len(numbers) && numbers[j] == numbers[i] {
+			j++
+		}
+		if j-i > best {
+			mode, best = numbers[i], j-i
+		}
+		i = j
+	}
+	return mode, true
+}
+
+func (ego *list) Percentile(p float64) float64 {
+	percentile, _ := ego.Ego().PercentileOk(p)
+	return percentile
+}
+
+func (ego *list) PercentileOk(p float64) (float64, bool) {
+	if p < 0 || p > 100 {
+		panic(fmt.Sprintf("percentile %v out of range from 0 to 100", p))
+	}
+	numbers := ego.sortedNumbers()
+	if len(numbers) == 0 {
+		return 0, false
+	}
+	rank := p / 100 * float64(len(numbers)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(numbers)-1 {
+		return numbers[lower], true
+	}
+	fraction := rank - float64(lower)
+	return numbers[lower] + fraction*(numbers[lower+1]-numbers[lower]), true
+}
+
+/*
+Computes a sum of squared deviations from the mean of the numbers in the list.
+
+Returns:
+  - computed sum,
+  - number of the numbers.
+*/
+func (ego *list) squaredDeviations() (float64, int) {
+	numbers := ego.numbers()
+	if len(numbers) == 0 {
+		return 0, 0
+	}
+	mean, _ := ego.Ego().AvgOk()
+	var sum float64
+	for _, val := range numbers {
+		sum += (val - mean) * (val - mean)
+	}
+	return sum, len(numbers)
+}
+
+func (ego *list) Variance() float64 {
+	variance, _ := ego.Ego().VarianceOk()
+	return variance
+}
+
+func (ego *list) VarianceOk() (float64, bool) {
+	sum, count := ego.squaredDeviations()
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+func (ego *list) SampleVariance() float64 {
+	variance, _ := ego.Ego().SampleVarianceOk()
+	return variance
+}
+
+func (ego *list) SampleVarianceOk() (float64, bool) {
+	sum, count := ego.squaredDeviations()
+	if count < 2 {
+		return 0, false
+	}
+	return sum / float64(count-1), true
+}
+
+func (ego *list) StdDev() float64 {
+	deviation, _ := ego.Ego().StdDevOk()
+	return deviation
+}
+
+func (ego *list) StdDevOk() (float64, bool) {
+	variance, ok := ego.Ego().VarianceOk()
+	return math.Sqrt(variance), ok
+}
+
+func (ego *list) SampleStdDev() float64 {
+	deviation, _ := ego.Ego().SampleStdDevOk()
+	return deviation
+}
+
+func (ego *list) SampleStdDevOk() (float64, bool) {
+	variance, ok := ego.Ego().SampleVarianceOk()
+	return math.Sqrt(variance), ok
+}
+
+func (ego *list) Histogram(bins int) List {
+	if bins < 1 {
+		panic("number of bins is lower than one")
+	}
+	min, _ := ego.Ego().MinOk()
+	max, _ := ego.Ego().MaxOk()
+	width := (max - min) / float64(bins)
+	counts := make([]int, bins)
+	for _, val := range ego.numbers() {
+		bin := bins - 1
+		if width > 0 && val < max {
+			bin = int((val - min) / width)
+			if bin >= bins {
+				bin = bins - 1
 			}
+		}
+		counts[bin]++
+	}
+	result := NewList()
+	for i, count := range counts {
+		upper := min + float64(i+1)*width
+		if i == bins-1 {
+			upper = max
+		}
+		result.Add(NewObject("min", min+float64(i)*width, "max", upper, "count", count))
+	}
+	return result
+}
+
+func (ego *list) CumSum() List {
+	result := NewList()
+	var sum float64
+	for _, val := range ego.numbers() {
+		sum += val
+		result.Add(sum)
+	}
+	return result
+}
+
+func (ego *list) Normalize() List {
+	min, _ := ego.Ego().MinOk()
+	max, _ := ego.Ego().MaxOk()
+	result := NewList()
+	for _, val := range ego.numbers() {
+		if max > min {
+			result.Add((val - min) / (max - min))
 		} else {
-			if item.(float64) > max.(float64) {
-				return item
-			} else {
-				return max
-			}
+			result.Add(0.0)
+		}
+	}
+	return result
+}
+
+/*
+Finds a position of the first number which is preferred to all other numbers of the list.
+
+Parameters:
+  - better - function deciding whether the first number is preferred to the second one.
+
+Returns:
+  - index of the found number (-1 if there are no numbers),
+  - the number (float).
+*/
+func (ego *list) argBest(better func(float64, float64) bool) (int, float64) {
+	index := -1
+	var best float64
+	for i, item := range ego.val {
+		var val float64
+		switch v := item.getVal().(type) {
+		case int:
+			val = float64(v)
+		case float64:
+			val = v
+		default:
+			continue
+		}
+		if index < 0 || better(val, best) {
+			index, best = i, val
 		}
-	}).(float64)
-	if present {
-		return max
-	} else {
-		return 0
 	}
+	return index, best
+}
+
+/*
+Checks if a number is lower than another one.
+
+Parameters:
+  - a - first number,
+  - b - second number.
+
+Returns:
+  - true if the first number is lower, false otherwise.
+*/
+func isLower(a float64, b float64) bool {
+	return a < b
+}
+
+/*
+Checks if a number is higher than another one.
+
+Parameters:
+  - a - first number,
+  - b - second number.
+
+Returns:
+  - true if the first number is higher, false otherwise.
+*/
+func isHigher(a float64, b float64) bool {
+	return a > b
+}
+
+func (ego *list) ArgMin() int {
+	index, _ := ego.argBest(isLower)
+	return index
+}
+
+func (ego *list) ArgMax() int {
+	index, _ := ego.argBest(isHigher)
+	return index
 }
 
 func (ego *list) ForEachAsync(function func(int, any)) List {
@@ -1175,3 +2143,172 @@ func (ego *list) TypeOfTF(tf string) Type {
 	}
 	return ego.Ego().TypeOf(int(integer))
 }
+
+/*
+Executes a function for every object of the list containing a value specified by a given tree form.
+Causes a panic if any of the elements is not an object.
+
+Parameters:
+  - tf - tree form of the value,
+  - function - function to execute, gets the object and the value.
+*/
+func (ego *list) forEachBy(tf string, function func(obj Object, val any)) {
+	for i := range ego.val {
+		obj := ego.Ego().GetObject(i)
+		if obj.TypeOfTF(tf) != TypeUndefined {
+			function(obj, obj.GetTF(tf))
+		}
+	}
+}
+
+/*
+Converts a value to a key of a grouping object.
+
+Parameters:
+  - val - value to convert.
+
+Returns:
+  - the string itself for strings, JSON serialization otherwise.
+*/
+func groupKey(val any) string {
+	if str, ok := val.(string); ok {
+		return str
+	}
+	return parseVal(val).serialize()
+}
+
+/*
+Collects values specified by a given tree form from all objects of the list.
+
+Parameters:
+  - tf - tree form of the value.
+
+Returns:
+  - list of the values.
+*/
+func (ego *list) pluck(tf string) List {
+	result := NewList()
+	ego.forEachBy(tf, func(_ Object, val any) {
+		result.Add(val)
+	})
+	return result
+}
+
+/*
+Executes a function for every object of the list containing a value specified by a given tree form.
+The value is converted to a key of a grouping object (nil becomes "null"). Causes a panic if any of the elements
+is not an object or if values of different types are converted to the same key (e.g. 1 and "1"),
+ints and floats are considered the same type.
+
+Parameters:
+  - tf - tree form of the value,
+  - function - function to execute, gets the object and the key.
+*/
+func (ego *list) forEachKey(tf string, function func(obj Object, key string)) {
+	kinds := map[string]Type{}
+	ego.forEachBy(tf, func(obj Object, val any) {
+		key, kind := groupKey(val), obj.TypeOfTF(tf)
+		if kind == TypeFloat {
+			kind = TypeInt
+		}
+		if known, exists := kinds[key]; exists && known != kind {
+			panic(fmt.Sprintf("values of different types specified by '%s' are converted to the same key '%s'", tf, key))
+		}
+		kinds[key] = kind
+		function(obj, key)
+	})
+}
+
+func (ego *list) GroupBy(tf string) Object {
+	result := NewObject()
+	ego.forEachKey(tf, func(obj Object, key string) {
+		if !result.KeyExists(key) {
+			result.Set(key, NewList())
+		}
+		result.GetList(key).Add(obj)
+	})
+	return result
+}
+
+func (ego *list) Partition(function func(any) bool) (List, List) {
+	accepted, rejected := NewList(), NewList()
+	for _, item := range ego.val {
+		if function(item.getVal()) {
+			accepted.Add(item.getVal())
+		} else {
+			rejected.Add(item.getVal())
+		}
+	}
+	return accepted, rejected
+}
+
+func (ego *list) CountBy(tf string) Object {
+	result := NewObject()
+	ego.forEachKey(tf, func(_ Object, key string) {
+		if result.KeyExists(key) {
+			result.Set(key, result.GetInt(key)+1)
+		} else {
+			result.Set(key, 1)
+		}
+	})
+	return result
+}
+
+func (ego *list) IndexBy(tf string) Object {
+	result := NewObject()
+	ego.forEachKey(tf, func(obj Object, key string) {
+		result.Set(key, obj)
+	})
+	return result
+}
+
+func (ego *list) SumBy(tf string) float64 {
+	return ego.pluck(tf).Sum()
+}
+
+func (ego *list) AvgBy(tf string) float64 {
+	return ego.pluck(tf).Avg()
+}
+
+func (ego *list) MinBy(tf string) float64 {
+	return ego.pluck(tf).Min()
+}
+
+func (ego *list) MaxBy(tf string) float64 {
+	return ego.pluck(tf).Max()
+}
+
+func (ego *list) AvgByOk(tf string) (float64, bool) {
+	return ego.pluck(tf).AvgOk()
+}
+
+func (ego *list) MinByOk(tf string) (float64, bool) {
+	return ego.pluck(tf).MinOk()
+}
+
+func (ego *list) MaxByOk(tf string) (float64, bool) {
+	return ego.pluck(tf).MaxOk()
+}
+
+func (ego *list) FlattenWith(options FlattenOptions) Object {
+	result := NewObject()
+	flattenValue(result, options, "", 0, ego.Ego())
+	return result
+}
+
+func (ego *list) Walk(function func(string, any, any) WalkAction) List {
+	walkChildren("", ego.Ego(), function)
+	return ego.Ego()
+}
+
+func (ego *list) WalkType(t Type, function func(string, any) WalkAction) List {
+	return ego.Ego().Walk(walkType(t, function))
+}
+
+func (ego *list) Transform(function func(string, any) any) List {
+	return transformChildren("", ego.Ego(), function).(List)
+}
+
+func (ego *list) TransformType(t Type, function func(string, any) any) List {
+	return ego.Ego().Transform(transformType(t, function))
+}
@@ -1,6 +1,8 @@
 package anytype_test
 
 import (
+	"math"
+	"math/rand"
 	"strconv"
 	"sync"
 	"testing"
@@ -40,6 +42,11 @@ func TestList(t *testing.T) {
 		if !List(1, 2, 3, 4).Delete(2, 0, 3).Equals(List(2)) {
 			t.Error("deleting multiple items does not work properly")
 		}
+		indexes := []int{2, 0, 3}
+		List(1, 2, 3, 4).Delete(indexes...)
+		if indexes[0] != 2 || indexes[1] != 0 || indexes[2] != 3 {
+			t.Error("deleting multiple items changes the given indexes")
+		}
 		if !List().Empty() {
 			t.Error("empty list should be empty")
 		}
@@ -66,6 +73,38 @@ func TestList(t *testing.T) {
 		}
 	})
 
+	t.Run("structural", func(t *testing.T) {
+		l := List(1, 2, 3, 4, 5)
+		if !l.Swap(0, -1).Equals(List(5, 2, 3, 4, 1)) {
+			t.Error("Swap does not work properly")
+		}
+		if !l.Move(0, 3).Equals(List(2, 3, 4, 5, 1)) || !l.Move(-1, 0).Equals(List(1, 2, 3, 4, 5)) {
+			t.Error("Move does not work properly")
+		}
+		if !l.Rotate(2).Equals(List(4, 5, 1, 2, 3)) || !l.Rotate(-7).Equals(List(1, 2, 3, 4, 5)) || !List().Rotate(1).Empty() {
+			t.Error("Rotate does not work properly")
+		}
+		if !l.Clone().Fill(0, 1, -1).Equals(List(1, 0, 0, 0, 5)) || !l.Clone().Fill(0, -2, 0).Equals(List(1, 2, 3, 0, 0)) {
+			t.Error("Fill does not work properly")
+		}
+		filled := List(nil, nil).Fill(Object("a", 1), 0, 0)
+		if !filled.Equals(List(Object("a", 1), Object("a", 1))) || filled.GetObject(0) == filled.GetObject(1) {
+			t.Error("Fill should copy objects into each position")
+		}
+		if !l.InsertAll(-1, "a", "b").Equals(List(1, 2, 3, 4, "a", "b", 5)) || !l.Clone().InsertAll(7, 6).Equals(List(1, 2, 3, 4, "a", "b", 5, 6)) {
+			t.Error("InsertAll does not work properly")
+		}
+		if removed := l.Splice(4, 2); !removed.Equals(List("a", "b")) || !l.Equals(List(1, 2, 3, 4, 5)) {
+			t.Error("Splice does not delete properly")
+		}
+		if removed := l.Splice(-2, 10, 0); !removed.Equals(List(4, 5)) || !l.Equals(List(1, 2, 3, 0)) {
+			t.Error("Splice does not replace properly")
+		}
+		if !l.Shift().Equals(List(2, 3, 0)) || !l.Unshift(0, 1).Equals(List(0, 1, 2, 3, 0)) {
+			t.Error("Shift or Unshift does not work properly")
+		}
+	})
+
 	t.Run("types", func(t *testing.T) {
 		l := List(
 			Object("test", 0),
@@ -158,6 +197,131 @@ func TestList(t *testing.T) {
 		if !List("b", "c", "a").Sort().Reverse().Equals(List("c", "b", "a")) {
 			t.Error("descending string sorting does not work properly")
 		}
+		mixed := List(Object("b", 1), "b", 2.5, List(1, 2), nil, Object("a", 2), true, 1, List(1), "a", false, 2, Object("a", 1))
+		if !mixed.Sort().Equals(List(nil, false, true, 1, 2, 2.5, "a", "b", List(1), List(1, 2), Object("a", 1), Object("a", 2), Object("b", 1))) {
+			t.Error("sorting of mixed list does not work properly")
+		}
+		if !List(1.0, 1, 0.5).Sort().Equals(List(0.5, 1, 1.0)) {
+			t.Error("sorting of mixed numbers does not work properly")
+		}
+		if !List(1, 3, 2).SortFunc(func(a, b any) bool { return a.(int) > b.(int) }).Equals(List(3, 2, 1)) {
+			t.Error("sorting with comparator does not work properly")
+		}
+		byLength := func(a, b any) bool { return len(a.(string)) < len(b.(string)) }
+		if !List("bb", "a", "cc", "d").SortStable(byLength).Equals(List("a", "d", "bb", "cc")) {
+			t.Error("stable sorting does not work properly")
+		}
+		people := List(
+			Object("name", "Bob", "age", 30),
+			Object("name", "Alice", "info", Object("age", 25)),
+			Object("name", "Carol", "age", 25),
+			Object("name", "Dave", "age", 30),
+		)
+		if !people.Clone().SortBy(".age", "-.name").Equals(List(
+			Object("name", "Alice", "info", Object("age", 25)),
+			Object("name", "Carol", "age", 25),
+			Object("name", "Dave", "age", 30),
+			Object("name", "Bob", "age", 30),
+		)) {
+			t.Error("sorting by keys does not work properly")
+		}
+		if !people.Clone().SortBy("+.info.age", ".name").GetObject(0).Equals(Object("name", "Bob", "age", 30)) {
+			t.Error("sorting by nested keys does not work properly")
+		}
+		sorted := List(1, 3, 5, 7)
+		if !sorted.IsSorted() || List(2, 1).IsSorted() || !List().IsSorted() {
+			t.Error("IsSorted does not work properly")
+		}
+		if sorted.BinarySearch(5) != 2 || sorted.BinarySearch(4) != -3 || sorted.BinarySearch(8) != -5 || sorted.BinarySearch(0) != -1 {
+			t.Error("binary search does not work properly")
+		}
+		if anytype.Compare(int64(1), 1) != 0 || anytype.Compare(math.NaN(), -1.0) >= 0 || anytype.Compare(math.NaN(), math.NaN()) != 0 {
+			t.Error("comparison of values does not work properly")
+		}
+		if anytype.Compare(Object("a", 1), Object("a", 1, "b", 0)) >= 0 || anytype.Compare(Object("b", 0), Object("a", 1)) <= 0 {
+			t.Error("comparison of objects does not work properly")
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		l := List(1, "a", 2, "b", 2)
+		isString := func(x any) bool {
+			_, ok := x.(string)
+			return ok
+		}
+		isNegative := func(x any) bool {
+			val, ok := x.(int)
+			return ok && val < 0
+		}
+		if x, ok := l.Find(isString); !ok || x != "a" {
+			t.Error("Find does not work properly")
+		}
+		if x, ok := l.Find(isNegative); ok || x != nil {
+			t.Error("Find should not find anything")
+		}
+		if x, ok := l.FindLast(isString); !ok || x != "b" {
+			t.Error("FindLast does not work properly")
+		}
+		if l.FindIndex(isString) != 1 || l.FindIndex(isNegative) != -1 {
+			t.Error("FindIndex does not work properly")
+		}
+		if l.LastIndexOf(2) != 4 || l.LastIndexOf(3) != -1 {
+			t.Error("LastIndexOf does not work properly")
+		}
+		if !l.Any(isString) || l.All(isString) || l.None(isString) || !l.None(isNegative) {
+			t.Error("Any, All or None does not work properly")
+		}
+		if !List().All(isString) || List().Any(isString) {
+			t.Error("Any or All does not work properly on empty list")
+		}
+		if l.CountIf(isString) != 2 {
+			t.Error("CountIf does not work properly")
+		}
+		if !l.Clone().RemoveIf(isString).Equals(List(1, 2, 2)) || !l.RetainIf(isString).Equals(List("a", "b")) || !l.Equals(List("a", "b")) {
+			t.Error("RemoveIf or RetainIf does not work properly")
+		}
+	})
+
+	t.Run("sets", func(t *testing.T) {
+		l := List(1, Object("a", List(1)), 2, List(Object("b", 2)), 1, Object("a", List(1)), List(Object("b", 2)), 1.0)
+		if !l.ContainsDeep(Object("a", List(1))) || l.ContainsDeep(Object("a", List(2))) || l.Contains(Object("a", List(1))) {
+			t.Error("ContainsDeep does not work properly")
+		}
+		if l.IndexOfDeep(List(Object("b", 2))) != 3 || l.IndexOfDeep(3) != -1 {
+			t.Error("IndexOfDeep does not work properly")
+		}
+		if !l.Distinct().Equals(List(1, Object("a", List(1)), 2, List(Object("b", 2)), 1.0)) {
+			t.Error("Distinct does not work properly")
+		}
+		first := List(1, 2, Object("x", 1, "y", 2), 2)
+		second := List(Object("y", 2, "x", 1), 3, 3, 1)
+		if !first.Union(second).Equals(List(1, 2, Object("x", 1, "y", 2), 3)) {
+			t.Error("Union does not work properly")
+		}
+		if !first.Intersect(second).Equals(List(1, Object("x", 1, "y", 2))) {
+			t.Error("Intersect does not work properly")
+		}
+		if !first.Difference(second).Equals(List(2)) {
+			t.Error("Difference does not work properly")
+		}
+		if !first.SymmetricDifference(second).Equals(List(2, 3)) {
+			t.Error("SymmetricDifference does not work properly")
+		}
+		if !first.Equals(List(1, 2, Object("x", 1, "y", 2), 2)) {
+			t.Error("set operations should not change the list")
+		}
+		people := List(
+			Object("name", "Alice", "address", Object("city", "Prague")),
+			Object("name", "Bob", "address", Object("city", "Brno")),
+			Object("name", "Carol", "address", Object("city", "Prague")),
+			Object("name", "Dave"),
+			Object("name", "Eve"),
+		)
+		if !people.DistinctBy(".address.city").Map(func(_ int, x any) any {
+			return x.(anytype.Object).GetString("name")
+		}).Equals(List("Alice", "Bob", "Dave")) {
+			t.Error("DistinctBy does not work properly")
+		}
 	})
 
 	t.Run("numeric", func(t *testing.T) {
@@ -206,6 +370,167 @@ func TestList(t *testing.T) {
 		if List(0, 5, 5, 10).Avg() != 5 {
 			t.Error("Avg does not work")
 		}
+		if List(2, "text", 4.0, nil).Avg() != 3 || List(2, "text", 4.0).Min() != 2 || List(2, "text", 4.0).Max() != 4 {
+			t.Error("non-numeric elements should be ignored")
+		}
+		if _, ok := List("text").AvgOk(); ok || List("text").Avg() != 0 {
+			t.Error("AvgOk does not work")
+		}
+		if val, ok := List(3, -1.5).MinOk(); !ok || val != -1.5 {
+			t.Error("MinOk does not work")
+		}
+		if _, ok := List().MaxOk(); ok {
+			t.Error("MaxOk does not work")
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		l := List(1, 2, 3, 4, 5, 6, 7, 8)
+		first := l.Clone().Shuffle(rand.New(rand.NewSource(42)))
+		second := l.Clone().Shuffle(rand.New(rand.NewSource(42)))
+		if !first.Equals(second) || !first.Clone().Sort().Equals(l) {
+			t.Error("Shuffle does not work properly")
+		}
+		sample := l.Sample(5, rand.New(rand.NewSource(1)))
+		if sample.Count() != 5 || sample.Distinct().Count() != 5 || !sample.Equals(l.Sample(5, rand.New(rand.NewSource(1)))) {
+			t.Error("Sample does not work properly")
+		}
+		if !l.Sample(8, nil).Sort().Equals(l) || !l.Sample(0, nil).Empty() {
+			t.Error("Sample does not work properly with edge sizes")
+		}
+		weighted := List(
+			Object("id", 1, "weight", 0),
+			Object("id", 2, "weight", 2.5),
+			Object("id", 3),
+			Object("id", 4, "weight", 1),
+		)
+		source := rand.New(rand.NewSource(7))
+		for i := 0; i < 20; i++ {
+			ids := weighted.WeightedSample(2, ".weight", source).Map(func(_ int, x any) any {
+				return x.(anytype.Object).GetInt("id")
+			}).Sort()
+			if !ids.Equals(List(2, 4)) {
+				t.Fatal("WeightedSample should choose only objects with positive weight")
+			}
+		}
+		counts := map[int]int{}
+		for i := 0; i < 1000; i++ {
+			counts[weighted.WeightedSample(1, ".weight", source).GetObject(0).GetInt("id")]++
+		}
+		if counts[2] < 600 || counts[4] < 150 {
+			t.Error("WeightedSample does not respect the weights")
+		}
+		if x := l.RandomElement(rand.New(rand.NewSource(3))); x != l.RandomElement(rand.New(rand.NewSource(3))) || !l.Contains(x) {
+			t.Error("RandomElement does not work properly")
+		}
+	})
+
+	t.Run("statistics", func(t *testing.T) {
+		l := List(4, 1, "skip", 3.0, 2, 5)
+		if l.Median() != 3 || List(4, 1, 2, 3).Median() != 2.5 {
+			t.Error("Median does not work")
+		}
+		if _, ok := List(true).MedianOk(); ok {
+			t.Error("MedianOk does not work")
+		}
+		if List(3, 1, 3.0, 1, 2).Mode() != 1 {
+			t.Error("Mode does not work")
+		}
+		if l.Percentile(0) != 1 || l.Percentile(100) != 5 || l.Percentile(25) != 2 || List(1, 2).Percentile(75) != 1.75 {
+			t.Error("Percentile does not work")
+		}
+		d := List(2, 4, 4, 4, 5, 5, 7, 9)
+		if d.Variance() != 4 || d.StdDev() != 2 || d.SampleVariance() != 32.0/7 || math.Abs(d.SampleStdDev()-math.Sqrt(32.0/7)) > 1e-12 {
+			t.Error("Variance or StdDev does not work")
+		}
+		if _, ok := List(1).SampleVarianceOk(); ok {
+			t.Error("sample variance of a single number should not be defined")
+		}
+		if val, ok := List(1).StdDevOk(); !ok || val != 0 {
+			t.Error("StdDevOk does not work")
+		}
+		histogram := List(0, 1, 2, 3, 4, "x", 10).Histogram(2)
+		if !histogram.Equals(List(
+			Object("min", 0.0, "max", 5.0, "count", 5),
+			Object("min", 5.0, "max", 10.0, "count", 1),
+		)) {
+			t.Error("Histogram does not work")
+		}
+		if List(1, 1).Histogram(3).GetObject(2).GetInt("count") != 2 {
+			t.Error("Histogram of equal numbers does not work")
+		}
+		if !List(1, "x", 2.5, 3).CumSum().Equals(List(1.0, 3.5, 6.5)) {
+			t.Error("CumSum does not work")
+		}
+		if !List(2, 4, 3.0).Normalize().Equals(List(0.0, 1.0, 0.5)) || !List(7, 7).Normalize().Equals(List(0.0, 0.0)) {
+			t.Error("Normalize does not work")
+		}
+		if l.ArgMin() != 1 || l.ArgMax() != 5 || List("x").ArgMax() != -1 {
+			t.Error("ArgMin or ArgMax does not work")
+		}
+	})
+
+	t.Run("grouping", func(t *testing.T) {
+		orders := List(
+			Object("id", 1, "customer", Object("name", "Alice"), "total", 10, "paid", true),
+			Object("id", 2, "customer", Object("name", "Bob"), "total", 5.5, "paid", false),
+			Object("id", 3, "customer", Object("name", "Alice"), "total", 20, "paid", true),
+			Object("id", 4, "total", 4.5),
+		)
+		groups := orders.GroupBy(".customer.name")
+		if groups.Count() != 2 || groups.GetList("Alice").Count() != 2 || groups.GetList("Bob").GetObject(0).GetInt("id") != 2 {
+			t.Error("GroupBy does not work properly")
+		}
+		if !orders.GroupBy(".paid").Keys().Sort().Equals(List("false", "true")) {
+			t.Error("GroupBy does not convert non-string keys properly")
+		}
+		if !orders.CountBy(".total").Equals(Object("10", 1, "5.5", 1, "20", 1, "4.5", 1)) {
+			t.Error("CountBy does not accept mixed ints and floats")
+		}
+		mixed := List(Object("s", "a"), Object("s", nil), Object("s", 1), Object("s", "a"))
+		if !mixed.CountBy(".s").Equals(Object("a", 2, "null", 1, "1", 1)) || mixed.GroupBy(".s").GetList("null").Count() != 1 {
+			t.Error("grouping by values of different types does not work properly")
+		}
+		if !orders.CountBy(".customer.name").Equals(Object("Alice", 2, "Bob", 1)) {
+			t.Error("CountBy does not work properly")
+		}
+		index := orders.IndexBy(".id")
+		if index.Count() != 4 || index.GetObject("3").GetInt("total") != 20 {
+			t.Error("IndexBy does not work properly")
+		}
+		if orders.IndexBy(".customer.name").GetObject("Alice").GetInt("id") != 3 {
+			t.Error("IndexBy should keep the last object for a key")
+		}
+		paid, unpaid := orders.Partition(func(x any) bool {
+			return x.(anytype.Object).TypeOf("paid") == anytype.TypeBool && x.(anytype.Object).GetBool("paid")
+		})
+		if paid.Count() != 2 || unpaid.Count() != 2 || unpaid.GetObject(1).GetInt("id") != 4 {
+			t.Error("Partition does not work properly")
+		}
+		if orders.SumBy(".total") != 40 || orders.AvgBy(".total") != 10 {
+			t.Error("SumBy or AvgBy does not work properly")
+		}
+		if orders.MinBy(".total") != 4.5 || orders.MaxBy(".total") != 20 || orders.MaxBy(".missing") != 0 {
+			t.Error("MinBy or MaxBy does not work properly")
+		}
+		if min, ok := orders.MinByOk(".total"); !ok || min != 4.5 {
+			t.Error("MinByOk does not work properly")
+		}
+		if max, ok := orders.MaxByOk(".total"); !ok || max != 20 {
+			t.Error("MaxByOk does not work properly")
+		}
+		if avg, ok := orders.AvgByOk(".total"); !ok || avg != 10 {
+			t.Error("AvgByOk does not work properly")
+		}
+		if _, ok := orders.MaxByOk(".missing"); ok {
+			t.Error("MaxByOk does not distinguish missing values")
+		}
+		if _, ok := orders.MinByOk(".missing"); ok {
+			t.Error("MinByOk does not distinguish missing values")
+		}
+		if _, ok := orders.AvgByOk(".missing"); ok {
+			t.Error("AvgByOk does not distinguish missing values")
+		}
 	})
 
 	t.Run("sublist", func(t *testing.T) {
@@ -440,6 +765,63 @@ func TestList(t *testing.T) {
 		}
 	})
 
+	t.Run("combinators", func(t *testing.T) {
+		nested := List(1, List(2, List(3, List(4))), 5)
+		if !nested.Flatten(1).Equals(List(1, 2, List(3, List(4)), 5)) || !nested.Flatten(-1).Equals(List(1, 2, 3, 4, 5)) || !nested.Flatten(0).Equals(nested) {
+			t.Error("Flatten does not work properly")
+		}
+		if !List(1, "a", 2).FlatMap(func(x any) anytype.List {
+			return List(x, x)
+		}).Equals(List(1, 1, "a", "a", 2, 2)) {
+			t.Error("FlatMap does not work properly")
+		}
+		if !List(1, "a", 2).FlatMapInts(func(x int) anytype.List {
+			return List(x, -x)
+		}).Equals(List(1, -1, 2, -2)) {
+			t.Error("FlatMapInts does not work properly")
+		}
+		if !List(Object("tags", List("a", "b")), 0, Object("tags", List("c"))).FlatMapObjects(func(x anytype.Object) anytype.List {
+			return x.GetList("tags")
+		}).Equals(List("a", "b", "c")) {
+			t.Error("FlatMapObjects does not work properly")
+		}
+		if !List(1, 2, 3).Zip(List("a", "b"), List(true, false, true)).Equals(List(List(1, "a", true), List(2, "b", false))) {
+			t.Error("Zip does not work properly")
+		}
+		if !List(1, 2, 3).Interleave(List("a"), List(true, false)).Equals(List(1, "a", true, 2, false, 3)) {
+			t.Error("Interleave does not work properly")
+		}
+		if !List(1, 2, 3, 4, 5).Chunk(2).Equals(List(List(1, 2), List(3, 4), List(5))) || !List().Chunk(3).Empty() {
+			t.Error("Chunk does not work properly")
+		}
+		if !List(1, 2, 3, 4, 5).Window(3, 1).Equals(List(List(1, 2, 3), List(2, 3, 4), List(3, 4, 5))) || !List(1, 2, 3, 4, 5).Window(2, 2).Equals(List(List(1, 2), List(3, 4))) {
+			t.Error("Window does not work properly")
+		}
+		small := func(x any) bool {
+			return x.(int) < 3
+		}
+		if !List(1, 2, 3, 1).TakeWhile(small).Equals(List(1, 2)) || !List(1, 2, 3, 1).DropWhile(small).Equals(List(3, 1)) {
+			t.Error("TakeWhile or DropWhile does not work properly")
+		}
+		if !List(1, 2, 3).Scan(0, func(acc any, x any) any {
+			return acc.(int) + x.(int)
+		}).Equals(List(1, 3, 6)) {
+			t.Error("Scan does not work properly")
+		}
+		if !List("a", 1, "b").ScanStrings("", func(acc string, x string) string {
+			return acc + x
+		}).Equals(List("a", "ab")) {
+			t.Error("ScanStrings does not work properly")
+		}
+		if !List(2, 2.5, 3).ScanInts(1, func(acc int, x int) int {
+			return acc * x
+		}).Equals(List(2, 6)) || !List(1, 0.5, 0.25).ScanFloats(0, func(acc float64, x float64) float64 {
+			return acc + x
+		}).Equals(List(0.5, 0.75)) {
+			t.Error("typed Scan variants do not work properly")
+		}
+	})
+
 	t.Run("filters", func(t *testing.T) {
 		l := List(
 			Object("test", 0),
@@ -578,9 +960,84 @@ func TestListPanics(t *testing.T) {
 		List().SubList(-1, 0)
 	})
 
-	t.Run("invalidSort", func(t *testing.T) {
-		defer catch("sorting unsupported list did not cause panic")
-		List(false).Sort()
+	t.Run("invalidSortBy", func(t *testing.T) {
+		defer catch("sorting list of non-objects by key did not cause panic")
+		List(Object(), false).SortBy(".test")
+	})
+
+	t.Run("invalidGroupBy", func(t *testing.T) {
+		defer catch("grouping list of non-objects did not cause panic")
+		List(Object(), "test").GroupBy(".test")
+	})
+
+	t.Run("mixedGroupBy", func(t *testing.T) {
+		defer catch("grouping by values of different types did not cause panic")
+		List(Object("k", 1), Object("k", "1")).GroupBy(".k")
+	})
+
+	t.Run("mixedCountBy", func(t *testing.T) {
+		defer catch("counting by values of different types did not cause panic")
+		List(Object("k", "null"), Object("k", nil)).CountBy(".k")
+	})
+
+	t.Run("mixedIndexBy", func(t *testing.T) {
+		defer catch("indexing by values of different types did not cause panic")
+		List(Object("k", true), Object("k", "true")).IndexBy(".k")
+	})
+
+	t.Run("invalidPercentile", func(t *testing.T) {
+		defer catch("percentile out of range did not cause panic")
+		List(1).Percentile(101)
+	})
+
+	t.Run("invalidHistogram", func(t *testing.T) {
+		defer catch("histogram with zero bins did not cause panic")
+		List(1).Histogram(0)
+	})
+
+	t.Run("invalidSwap", func(t *testing.T) {
+		defer catch("swapping non-existing element did not cause panic")
+		List(1, 2).Swap(0, -3)
+	})
+
+	t.Run("invalidSplice", func(t *testing.T) {
+		defer catch("splicing negative number of elements did not cause panic")
+		List(1, 2).Splice(0, -1)
+	})
+
+	t.Run("invalidShift", func(t *testing.T) {
+		defer catch("shifting empty list did not cause panic")
+		List().Shift()
+	})
+
+	t.Run("invalidSample", func(t *testing.T) {
+		defer catch("sample larger than the list did not cause panic")
+		List(1).Sample(2, nil)
+	})
+
+	t.Run("invalidWeightedSample", func(t *testing.T) {
+		defer catch("negative weight did not cause panic")
+		List(Object("w", -1)).WeightedSample(0, ".w", nil)
+	})
+
+	t.Run("invalidRandomElement", func(t *testing.T) {
+		defer catch("random element of empty list did not cause panic")
+		List().RandomElement(nil)
+	})
+
+	t.Run("invalidChunk", func(t *testing.T) {
+		defer catch("chunk size of zero did not cause panic")
+		List(1).Chunk(0)
+	})
+
+	t.Run("invalidWindow", func(t *testing.T) {
+		defer catch("window step of zero did not cause panic")
+		List(1).Window(1, 0)
+	})
+
+	t.Run("invalidDistinctBy", func(t *testing.T) {
+		defer catch("distinct by key on list of non-objects did not cause panic")
+		List(Object(), 1).DistinctBy(".test")
 	})
 
 	t.Run("invalidGetTF", func(t *testing.T) {
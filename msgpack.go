@@ -0,0 +1,447 @@
+/*
+AnyType Library for Go
+MessagePack codec
+*/
+
+package anytype
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+/*
+MsgPackEncoder writes values to a stream in MessagePack.
+*/
+type MsgPackEncoder struct {
+	writer   io.Writer
+	maxDepth int
+}
+
+/*
+NewMsgPackEncoder creates a new MessagePack encoder.
+
+Parameters:
+  - writer - destination of the encoded values.
+
+Returns:
+  - pointer to the created encoder.
+*/
+func NewMsgPackEncoder(writer io.Writer) *MsgPackEncoder {
+	return &MsgPackEncoder{writer: writer, maxDepth: DefaultMaxDepth}
+}
+
+/*
+SetMaxDepth sets the maximum nesting depth of objects and lists.
+
+Parameters:
+  - depth - maximum depth.
+
+Returns:
+  - the encoder.
+*/
+func (ego *MsgPackEncoder) SetMaxDepth(depth int) *MsgPackEncoder {
+	ego.maxDepth = depth
+	return ego
+}
+
+/*
+Encode writes a single value to the stream.
+Integers are encoded as integers, floats as floating-point numbers (float 32 if lossless), keys of objects are sorted.
+
+Parameters:
+  - value - value to encode (object, list or any value storable in them).
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *MsgPackEncoder) Encode(value any) error {
+	data, err := appendMsgPack(nil, parseVal(value).getVal(), ego.maxDepth)
+	if err != nil {
+		return err
+	}
+	_, err = ego.writer.Write(data)
+	return err
+}
+
+/*
+Appends a header of a string, an array or a map to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - length - length of the item,
+  - fix - format of the fixed-size variant,
+  - fixLimit - maximum length of the fixed-size variant,
+  - formats - formats of the 8-bit (0 if not available), 16-bit and 32-bit variants.
+
+Returns:
+  - extended buffer.
+*/
+func appendMsgPackHeader(data []byte, length int, fix byte, fixLimit int, formats [3]byte) []byte {
+	switch {
+	case length <= fixLimit:
+		return append(data, fix|byte(length))
+	case length <= math.MaxUint8 && formats[0] != 0:
+		return append(data, formats[0], byte(length))
+	case length <= math.MaxUint16:
+		return appendUint(append(data, formats[1]), uint64(length), 2)
+	}
+	return appendUint(append(data, formats[2]), uint64(length), 4)
+}
+
+/*
+Appends an encoded integer to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - value - the integer.
+
+Returns:
+  - extended buffer.
+*/
+func appendMsgPackInt(data []byte, value int) []byte {
+	wide := int64(value)
+	switch {
+	case wide >= -32 && wide <= math.MaxInt8:
+		return append(data, byte(wide))
+	case wide >= 0 && wide <= math.MaxUint8:
+		return append(data, 0xcc, byte(wide))
+	case wide >= 0 && wide <= math.MaxUint16:
+		return appendUint(append(data, 0xcd), uint64(wide), 2)
+	case wide >= 0 && wide <= math.MaxUint32:
+		return appendUint(append(data, 0xce), uint64(wide), 4)
+	case wide >= 0:
+		return appendUint(append(data, 0xcf), uint64(wide), 8)
+	case wide >= math.MinInt8:
+		return append(data, 0xd0, byte(wide))
+	case wide >= math.MinInt16:
+		return appendUint(append(data, 0xd1), uint64(wide), 2)
+	case wide >= math.MinInt32:
+		return appendUint(append(data, 0xd2), uint64(wide), 4)
+	}
+	return appendUint(append(data, 0xd3), uint64(wide), 8)
+}
+
+/*
+Appends an encoded value to a buffer.
+
+Parameters:
+  - data - the buffer,
+  - value - value to encode,
+  - depth - remaining nesting depth.
+
+Returns:
+  - extended buffer,
+  - error if the maximum depth is exceeded.
+*/
+func appendMsgPack(data []byte, value any, depth int) ([]byte, error) {
+	switch typed := value.(type) {
+	case nil:
+		return append(data, 0xc0), nil
+	case bool:
+		if typed {
+			return append(data, 0xc3), nil
+		}
+		return append(data, 0xc2), nil
+	case int:
+		return appendMsgPackInt(data, typed), nil
+	case float64:
+		if single := float32(typed); float64(single) == typed || math.IsNaN(typed) {
+			return appendUint(append(data, 0xca), uint64(math.Float32bits(single)), 4), nil
+		}
+		return appendUint(append(data, 0xcb), math.Float64bits(typed), 8), nil
+	case string:
+		return append(appendMsgPackHeader(data, len(typed), 0xa0, 31, [3]byte{0xd9, 0xda, 0xdb}), typed...), nil
+	}
+	if depth <= 0 {
+		return nil, fmt.Errorf("maximum depth exceeded")
+	}
+	var err error
+	switch typed := value.(type) {
+	case List:
+		data = appendMsgPackHeader(data, typed.Count(), 0x90, 15, [3]byte{0, 0xdc, 0xdd})
+		for i := 0; i < typed.Count() && err == nil; i++ {
+			data, err = appendMsgPack(data, typed.Get(i), depth-1)
+		}
+	case Object:
+		data = appendMsgPackHeader(data, typed.Count(), 0x80, 15, [3]byte{0, 0xde, 0xdf})
+		for _, key := range sortedKeysOf(typed) {
+			data = append(appendMsgPackHeader(data, len(key), 0xa0, 31, [3]byte{0xd9, 0xda, 0xdb}), key...)
+			if data, err = appendMsgPack(data, typed.Get(key), depth-1); err != nil {
+				break
+			}
+		}
+	}
+	return data, err
+}
+
+/*
+MsgPackDecoder reads MessagePack values from a stream.
+*/
+type MsgPackDecoder struct {
+	reader   *binaryReader
+	maxDepth int
+}
+
+/*
+NewMsgPackDecoder creates a new MessagePack decoder.
+The decoder may read data beyond the decoded values if the reader does not implement io.ByteReader.
+
+Parameters:
+  - reader - source of the encoded values.
+
+Returns:
+  - pointer to the created decoder.
+*/
+func NewMsgPackDecoder(reader io.Reader) *MsgPackDecoder {
+	return &MsgPackDecoder{reader: newBinaryReader(reader, "MessagePack"), maxDepth: DefaultMaxDepth}
+}
+
+/*
+SetMaxDepth sets the maximum nesting depth of arrays and maps.
+
+Parameters:
+  - depth - maximum depth.
+
+Returns:
+  - the decoder.
+*/
+func (ego *MsgPackDecoder) SetMaxDepth(depth int) *MsgPackDecoder {
+	ego.maxDepth = depth
+	return ego
+}
+
+/*
+Decode reads a single value from the stream.
+Maps become objects (keys have to be strings), arrays become lists, binary data become strings,
+float 32 and float 64 become float64. Extension types are not supported.
+
+Returns:
+  - decoded value,
+  - error if any occurred (io.EOF if there are no more values).
+*/
+func (ego *MsgPackDecoder) Decode() (any, error) {
+	format, err := ego.reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	return ego.decode(format, ego.maxDepth)
+}
+
+/*
+Reads the format of the next value and decodes it.
+
+Parameters:
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *MsgPackDecoder) decodeNext(depth int) (any, error) {
+	format, err := ego.reader.readByte()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return ego.decode(format, depth)
+}
+
+/*
+Decodes a value with an already read format byte.
+
+Parameters:
+  - format - format byte of the value,
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func (ego *MsgPackDecoder) decode(format byte, depth int) (any, error) {
+	switch {
+	case format <= 0x7f:
+		return int(format), nil
+	case format >= 0xe0:
+		return int(int8(format)), nil
+	case format&0xe0 == 0xa0:
+		return ego.decodeString(uint64(format & 0x1f))
+	case format&0xf0 == 0x90:
+		return ego.decodeArray(uint64(format&0x0f), depth)
+	case format&0xf0 == 0x80:
+		return ego.decodeMap(uint64(format&0x0f), depth)
+	}
+	switch format {
+	case 0xc0:
+		return nil, nil
+	case 0xc2, 0xc3:
+		return format == 0xc3, nil
+	case 0xca:
+		single, err := ego.reader.readUint(4)
+		return float64(math.Float32frombits(uint32(single))), err
+	case 0xcb:
+		double, err := ego.reader.readUint(8)
+		return math.Float64frombits(double), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		value, err := ego.reader.readUint(1 << (format - 0xcc))
+		if err == nil && value > math.MaxInt {
+			return nil, ego.reader.errorf("integer out of range")
+		}
+		return int(value), err
+	case 0xd0:
+		value, err := ego.reader.readUint(1)
+		return int(int8(value)), err
+	case 0xd1:
+		value, err := ego.reader.readUint(2)
+		return int(int16(value)), err
+	case 0xd2:
+		value, err := ego.reader.readUint(4)
+		return int(int32(value)), err
+	case 0xd3:
+		value, err := ego.reader.readUint(8)
+		if err == nil && (int64(value) > math.MaxInt || int64(value) < math.MinInt) {
+			return nil, ego.reader.errorf("integer out of range")
+		}
+		return int(int64(value)), err
+	case 0xc4, 0xc5, 0xc6, 0xd9, 0xda, 0xdb:
+		size := map[byte]int{0xc4: 1, 0xc5: 2, 0xc6: 4, 0xd9: 1, 0xda: 2, 0xdb: 4}[format]
+		length, err := ego.reader.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		return ego.decodeString(length)
+	case 0xdc, 0xdd, 0xde, 0xdf:
+		length, err := ego.reader.readUint(2 << (format & 1))
+		if err != nil {
+			return nil, err
+		}
+		if format < 0xde {
+			return ego.decodeArray(length, depth)
+		}
+		return ego.decodeMap(length, depth)
+	}
+	return nil, ego.reader.errorf("unsupported format 0x%02x", format)
+}
+
+/*
+Decodes a string of a given length.
+
+Parameters:
+  - length - length of the string in bytes.
+
+Returns:
+  - decoded string,
+  - error if any occurred.
+*/
+func (ego *MsgPackDecoder) decodeString(length uint64) (any, error) {
+	data, err := ego.reader.readBytes(length)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+/*
+Decodes an array of a given length.
+
+Parameters:
+  - length - number of elements,
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded list,
+  - error if any occurred.
+*/
+func (ego *MsgPackDecoder) decodeArray(length uint64, depth int) (any, error) {
+	if depth <= 0 {
+		return nil, ego.reader.errorf("maximum depth exceeded")
+	}
+	result := NewList()
+	for i := uint64(0); i < length; i++ {
+		value, err := ego.decodeNext(depth - 1)
+		if err != nil {
+			return nil, err
+		}
+		result.Add(value)
+	}
+	return result, nil
+}
+
+/*
+Decodes a map of a given length.
+
+Parameters:
+  - length - number of key-value pairs,
+  - depth - remaining nesting depth.
+
+Returns:
+  - decoded object,
+  - error if any occurred.
+*/
+func (ego *MsgPackDecoder) decodeMap(length uint64, depth int) (any, error) {
+	if depth <= 0 {
+		return nil, ego.reader.errorf("maximum depth exceeded")
+	}
+	result := NewObject()
+	for i := uint64(0); i < length; i++ {
+		format, err := ego.reader.readByte()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if format&0xe0 != 0xa0 && format != 0xd9 && format != 0xda && format != 0xdb {
+			return nil, ego.reader.errorf("map key is not a string")
+		}
+		key, err := ego.decode(format, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := ego.decodeNext(depth - 1)
+		if err != nil {
+			return nil, err
+		}
+		result.Set(key.(string), value)
+	}
+	return result, nil
+}
+
+/*
+MarshalMsgPack encodes a value in MessagePack.
+Integers are encoded as integers, floats as floating-point numbers (float 32 if lossless), keys of objects are sorted.
+
+Parameters:
+  - value - value to encode (object, list or any value storable in them).
+
+Returns:
+  - encoded data,
+  - error if the maximum depth (DefaultMaxDepth) is exceeded.
+*/
+func MarshalMsgPack(value any) ([]byte, error) {
+	var result bytes.Buffer
+	err := NewMsgPackEncoder(&result).Encode(value)
+	return result.Bytes(), err
+}
+
+/*
+UnmarshalMsgPack decodes a MessagePack value.
+Maps become objects (keys have to be strings), arrays become lists, binary data become strings,
+float 32 and float 64 become float64. Extension types are not supported.
+
+Parameters:
+  - data - data to decode (exactly one value).
+
+Returns:
+  - decoded value,
+  - error if any occurred.
+*/
+func UnmarshalMsgPack(data []byte) (any, error) {
+	decoder := NewMsgPackDecoder(bytes.NewReader(data))
+	value, err := decoder.Decode()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if decoder.reader.offset < int64(len(data)) {
+		return nil, decoder.reader.errorf("unexpected data after the value")
+	}
+	return value, nil
+}
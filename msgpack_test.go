@@ -0,0 +1,190 @@
+package anytype_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestMsgPack(t *testing.T) {
+
+	t.Run("encode", func(t *testing.T) {
+		for value, expected := range map[any]string{
+			0:                       "00",
+			127:                     "7f",
+			128:                     "cc 80",
+			256:                     "cd 0100",
+			65536:                   "ce 00010000",
+			-1:                      "ff",
+			-32:                     "e0",
+			-33:                     "d0 df",
+			-129:                    "d1 ff7f",
+			-32769:                  "d2 ffff7fff",
+			1.5:                     "ca 3fc00000",
+			1.1:                     "cb 3ff199999999999a",
+			nil:                     "c0",
+			false:                   "c2",
+			true:                    "c3",
+			"":                      "a0",
+			"abc":                   "a3 616263",
+			strings.Repeat("a", 32): "d9 20" + strings.Repeat("61", 32),
+		} {
+			data, err := anytype.MarshalMsgPack(value)
+			if err != nil || !bytes.Equal(data, decodeHex(t, expected)) {
+				t.Errorf("encoding of %v does not work properly: %x", value, data)
+			}
+		}
+		if strconv.IntSize == 64 {
+			large, small := int64(1)<<32, int64(math.MinInt64)
+			for value, expected := range map[int]string{int(large): "cf 0000000100000000", int(small): "d3 8000000000000000"} {
+				data, err := anytype.MarshalMsgPack(value)
+				if err != nil || !bytes.Equal(data, decodeHex(t, expected)) {
+					t.Errorf("encoding of %d does not work properly: %x", value, data)
+				}
+			}
+		}
+		data, err := anytype.MarshalMsgPack(Object("schema", 0, "compact", true))
+		if err != nil || !bytes.Equal(data, decodeHex(t, "82 a7 636f6d70616374 c3 a6 736368656d61 00")) {
+			t.Errorf("encoding of objects does not work properly: %x", data)
+		}
+		data, err = anytype.MarshalMsgPack(anytype.NewListOf(1, 16))
+		if err != nil || !bytes.Equal(data, decodeHex(t, "dc 0010"+strings.Repeat("01", 16))) {
+			t.Errorf("encoding of lists does not work properly: %x", data)
+		}
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		for encoded, expected := range map[string]any{
+			"00":                  0,
+			"cc ff":               255,
+			"d0 80":               -128,
+			"d1 8000":             -32768,
+			"ca 3fc00000":         1.5,
+			"cb 3ff199999999999a": 1.1,
+			"c0":                  nil,
+			"c3":                  true,
+			"a3 616263":           "abc",
+			"da 0003 616263":      "abc",
+			"c4 03 010203":        "\x01\x02\x03",
+			"c6 00000000":         "",
+		} {
+			value, err := anytype.UnmarshalMsgPack(decodeHex(t, encoded))
+			if err != nil || value != expected {
+				t.Errorf("decoding of %s does not work properly: %v %v", encoded, value, err)
+			}
+		}
+		value, err := anytype.UnmarshalMsgPack(decodeHex(t, "82 a1 61 93 01 c0 a1 62 a1 62 de 0001 a1 63 90"))
+		if err != nil || !value.(anytype.Object).Equals(Object("a", List(1, nil, "b"), "b", Object("c", List()))) {
+			t.Error("decoding of collections does not work properly")
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o := Object(
+			"int", 42,
+			"negative", math.MinInt,
+			"max", math.MaxInt,
+			"float", 2.0,
+			"small", 1e-300,
+			"inf", math.Inf(1),
+			"string", strings.Repeat("long ", 20000),
+			"list", List(nil, true, false, List(), Object()),
+			"object", Object("nested", Object("deep", List(1, 2.5))),
+		)
+		data, err := anytype.MarshalMsgPack(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := anytype.UnmarshalMsgPack(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed := value.(anytype.Object)
+		if !parsed.Equals(o) || parsed.TypeOf("float") != anytype.TypeFloat || parsed.TypeOf("int") != anytype.TypeInt {
+			t.Error("encoded value does not round-trip")
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		var buffer bytes.Buffer
+		encoder := anytype.NewMsgPackEncoder(&buffer)
+		for _, value := range []any{Object("a", 1), List(2), "three", nil} {
+			if err := encoder.Encode(value); err != nil {
+				t.Fatal(err)
+			}
+		}
+		decoder := anytype.NewMsgPackDecoder(&buffer)
+		values := List()
+		for {
+			value, err := decoder.Decode()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			values.Add(value)
+		}
+		if !values.Equals(List(Object("a", 1), List(2), "three", nil)) {
+			t.Error("streaming does not work properly")
+		}
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		deep := Object()
+		for i := 0; i < 10; i++ {
+			deep = Object("a", deep)
+		}
+		var buffer bytes.Buffer
+		if err := anytype.NewMsgPackEncoder(&buffer).SetMaxDepth(10).Encode(deep); err == nil {
+			t.Error("maximum depth of encoder is not checked")
+		}
+		if err := anytype.NewMsgPackEncoder(&buffer).SetMaxDepth(11).Encode(deep); err != nil {
+			t.Error("maximum depth of encoder is checked incorrectly")
+		}
+		if _, err := anytype.NewMsgPackDecoder(bytes.NewReader(buffer.Bytes())).SetMaxDepth(10).Decode(); err == nil {
+			t.Error("maximum depth of decoder is not checked")
+		}
+		if _, err := anytype.NewMsgPackDecoder(bytes.NewReader(buffer.Bytes())).SetMaxDepth(11).Decode(); err != nil {
+			t.Error("maximum depth of decoder is checked incorrectly")
+		}
+		bomb := bytes.Repeat([]byte{0x91}, anytype.DefaultMaxDepth+1)
+		if _, err := anytype.UnmarshalMsgPack(append(bomb, 0x00)); err == nil || !strings.Contains(err.Error(), "depth") {
+			t.Error("default maximum depth of decoder is not checked")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, encoded := range []string{
+			"",
+			"cc",
+			"a2 61",
+			"92 01",
+			"81 01 02",
+			"81 a1 61",
+			"cf ffffffffffffffff",
+			"c1",
+			"d4 01 02",
+			"c7 01 01 02",
+			"01 02",
+			"db ffffffff 00",
+		} {
+			if _, err := anytype.UnmarshalMsgPack(decodeHex(t, encoded)); err == nil {
+				t.Errorf("decoder did not return expected error for %s", encoded)
+			}
+		}
+		if _, err := anytype.UnmarshalMsgPack(decodeHex(t, "92 01")); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Error("truncated data are not reported properly")
+		}
+		if _, err := anytype.UnmarshalMsgPack(decodeHex(t, "81 01 02")); err == nil || !strings.HasSuffix(err.Error(), "at offset 2") {
+			t.Error("offset of an error is not reported properly")
+		}
+	})
+
+}
@@ -0,0 +1,228 @@
+/*
+AnyType Library for Go
+Newline-delimited JSON (JSON Lines) reader and writer
+*/
+
+package anytype
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+NDJSONScanner reads newline-delimited JSON, one object or list per line.
+Blank lines are ignored. Lines may be of any length.
+*/
+type NDJSONScanner struct {
+	reader  *bufio.Reader
+	skip    bool
+	handler func(err error)
+	line    int
+	value   any
+	err     error
+}
+
+/*
+NewNDJSONScanner creates a new NDJSON scanner.
+
+Parameters:
+  - reader - source of the data.
+
+Returns:
+  - pointer to the created scanner.
+*/
+func NewNDJSONScanner(reader io.Reader) *NDJSONScanner {
+	return &NDJSONScanner{reader: bufio.NewReader(reader)}
+}
+
+/*
+SkipErrors makes the scanner skip invalid lines instead of stopping at them.
+
+Parameters:
+  - handler - function called with the error of each skipped line (may be nil).
+
+Returns:
+  - the scanner.
+*/
+func (ego *NDJSONScanner) SkipErrors(handler func(err error)) *NDJSONScanner {
+	ego.skip = true
+	ego.handler = handler
+	return ego
+}
+
+/*
+Parses a single line of NDJSON.
+
+Parameters:
+  - text - the line,
+  - line - number of the line.
+
+Returns:
+  - parsed object or list (nil for a blank line),
+  - error if any occurred.
+*/
+func parseNDJSONLine(text string, line int) (any, error) {
+	if line == 1 {
+		text = strings.TrimPrefix(text, "\ufeff")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	if !utf8.ValidString(text) {
+		return nil, fmt.Errorf("not an UTF-8 encoding on line %d", line)
+	}
+	var value any
+	var end int
+	var err error
+	current := line
+	switch text[0] {
+	case '{':
+		value, end, err = parseObject(text, &current, NewObject)
+	case '[':
+		value, end, err = parseList(text, &current, NewObject)
+	default:
+		return nil, fmt.Errorf("not a valid JSON - expecting '{' or '[', got '%c' on line %d", []rune(text)[0], line)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rest := strings.TrimSpace(text[end+1:]); rest != "" {
+		return nil, fmt.Errorf("not a valid JSON - unexpected '%c' after the value on line %d", []rune(rest)[0], line)
+	}
+	return value, nil
+}
+
+/*
+Scan advances the scanner to the next object or list, which is then available through the Value method.
+Scanning stops at the end of the input or at the first error (unless the errors are skipped).
+
+Returns:
+  - true if a value has been read, false otherwise.
+*/
+func (ego *NDJSONScanner) Scan() bool {
+	ego.value = nil
+	for ego.err == nil {
+		text, err := ego.reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			ego.err = err
+			break
+		}
+		if text == "" {
+			break
+		}
+		ego.line++
+		value, err := parseNDJSONLine(text, ego.line)
+		switch {
+		case err != nil && ego.skip:
+			if ego.handler != nil {
+				ego.handler(err)
+			}
+		case err != nil:
+			ego.err = err
+		case value != nil:
+			ego.value = value
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Value gives the object or list read by the last call of Scan.
+
+Returns:
+  - Object or List, nil if there is none.
+*/
+func (ego *NDJSONScanner) Value() any {
+	return ego.value
+}
+
+/*
+Line gives the number of the last read line.
+
+Returns:
+  - line number (starting from 1).
+*/
+func (ego *NDJSONScanner) Line() int {
+	return ego.line
+}
+
+/*
+Err gives the error which stopped the scanning.
+
+Returns:
+  - the error, nil if the end of the input has been reached.
+*/
+func (ego *NDJSONScanner) Err() error {
+	return ego.err
+}
+
+/*
+NDJSONWriter writes objects and lists as newline-delimited JSON.
+*/
+type NDJSONWriter struct {
+	writer io.Writer
+}
+
+/*
+NewNDJSONWriter creates a new NDJSON writer.
+The data are written directly, the writer may be buffered for better performance.
+
+Parameters:
+  - writer - destination of the data.
+
+Returns:
+  - pointer to the created writer.
+*/
+func NewNDJSONWriter(writer io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{writer: writer}
+}
+
+/*
+Write writes a single object or list as one line.
+
+Parameters:
+  - value - Object or List to write.
+
+Returns:
+  - error if any occurred (including a value which is neither an object nor a list).
+*/
+func (ego *NDJSONWriter) Write(value any) error {
+	var line string
+	switch typed := value.(type) {
+	case Object:
+		line = typed.String()
+	case List:
+		line = typed.String()
+	default:
+		return fmt.Errorf("%T cannot be written as NDJSON", value)
+	}
+	_, err := io.WriteString(ego.writer, line+"\n")
+	return err
+}
+
+/*
+ToNDJSON writes all elements of a list as newline-delimited JSON, one element per line.
+
+Parameters:
+  - list - list of objects and lists to write,
+  - writer - destination of the data.
+
+Returns:
+  - error if any occurred (including an element which is neither an object nor a list).
+*/
+func ToNDJSON(list List, writer io.Writer) error {
+	buffered := bufio.NewWriter(writer)
+	ndjson := NewNDJSONWriter(buffered)
+	for i := 0; i < list.Count(); i++ {
+		if err := ndjson.Write(list.Get(i)); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return buffered.Flush()
+}
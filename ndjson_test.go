@@ -0,0 +1,123 @@
+package anytype_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestNDJSON(t *testing.T) {
+
+	t.Run("scan", func(t *testing.T) {
+		scanner := anytype.NewNDJSONScanner(strings.NewReader("\ufeff{\"a\": 1}\r\n\n  [1, \"x\"]  \n{\"nested\": {\"b\": [true]}}"))
+		values := List()
+		lines := List()
+		for scanner.Scan() {
+			values.Add(scanner.Value())
+			lines.Add(scanner.Line())
+		}
+		if scanner.Err() != nil || scanner.Value() != nil {
+			t.Error("end of input is not handled properly")
+		}
+		if !values.Equals(List(Object("a", 1), List(1, "x"), Object("nested", Object("b", List(true))))) {
+			t.Error("scanning does not work properly")
+		}
+		if !lines.Equals(List(1, 3, 4)) {
+			t.Error("line numbers are not counted properly")
+		}
+		long := `{"text":"` + strings.Repeat("x", 1<<20) + `"}`
+		scanner = anytype.NewNDJSONScanner(strings.NewReader(long + "\n" + long + "\n"))
+		count := 0
+		for scanner.Scan() {
+			count += len(scanner.Value().(anytype.Object).GetString("text"))
+		}
+		if scanner.Err() != nil || count != 2<<20 {
+			t.Error("scanning of long lines does not work properly")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for ndjson, message := range map[string]string{
+			"{\"a\":1}\n{\"a\":":        "unexpected end of input on line 2",
+			"{\"a\":1}\n\n{} []":        "unexpected '[' after the value on line 3",
+			"42":                        "expecting '{' or '[', got '4' on line 1",
+			"[]\n[]\n{\"a\" 1}":         "expecting ':', got '1' on line 3",
+			"{}\n\"\xff\"":              "not an UTF-8 encoding on line 2",
+			"{}\n{\"a\": [1, nul]}\n{}": "invalid value 'nul' on line 2",
+		} {
+			scanner := anytype.NewNDJSONScanner(strings.NewReader(ndjson))
+			for scanner.Scan() {
+			}
+			if scanner.Err() == nil || !strings.HasSuffix(scanner.Err().Error(), message) {
+				t.Errorf("wrong error reported for %q: %v", ndjson, scanner.Err())
+			}
+			if scanner.Scan() {
+				t.Error("scanning continues after an error")
+			}
+		}
+		scanner := anytype.NewNDJSONScanner(iotest.ErrReader(io.ErrClosedPipe))
+		if scanner.Scan() || !errors.Is(scanner.Err(), io.ErrClosedPipe) {
+			t.Error("reading error is not reported properly")
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		skipped := 0
+		scanner := anytype.NewNDJSONScanner(strings.NewReader("{\"a\":1}\nbroken\n{\"a\":\n{\"a\":2}\n")).SkipErrors(func(err error) {
+			skipped++
+			if !strings.Contains(err.Error(), "line") {
+				t.Error("skipped error does not contain the line")
+			}
+		})
+		values := List()
+		for scanner.Scan() {
+			values.Add(scanner.Value())
+		}
+		if scanner.Err() != nil || skipped != 2 || !values.Equals(List(Object("a", 1), Object("a", 2))) {
+			t.Error("skipping of errors does not work properly")
+		}
+		scanner = anytype.NewNDJSONScanner(strings.NewReader("x\n[1]\ny")).SkipErrors(nil)
+		values = List()
+		for scanner.Scan() {
+			values.Add(scanner.Value())
+		}
+		if scanner.Err() != nil || !values.Equals(List(List(1))) {
+			t.Error("skipping of errors without a handler does not work properly")
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		l := List(Object("message", "line\nbreak", "level", 3), List(1, 2.5, nil), Object())
+		var buffer bytes.Buffer
+		if err := anytype.ToNDJSON(l, &buffer); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(buffer.String(), "\n") != 3 || !strings.HasSuffix(buffer.String(), "\n[1,2.5,null]\n{}\n") {
+			t.Error("list is not written properly")
+		}
+		scanner := anytype.NewNDJSONScanner(&buffer)
+		parsed := List()
+		for scanner.Scan() {
+			parsed.Add(scanner.Value())
+		}
+		if scanner.Err() != nil || !parsed.Equals(l) {
+			t.Error("written NDJSON does not round-trip")
+		}
+		writer := anytype.NewNDJSONWriter(&buffer)
+		if writer.Write(Object("a", 1)) != nil || buffer.String() != "{\"a\":1}\n" {
+			t.Error("writer does not work properly")
+		}
+		if writer.Write("text") == nil {
+			t.Error("writing of a scalar does not return expected error")
+		}
+		if err := anytype.ToNDJSON(List(Object(), 1), &buffer); err == nil || !strings.Contains(err.Error(), "element 1") {
+			t.Error("writing of a list with scalars does not return expected error")
+		}
+	})
+
+}
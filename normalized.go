@@ -0,0 +1,209 @@
+/*
+AnyType Library for Go
+Object with normalized keys
+*/
+
+package anytype
+
+import (
+	"strings"
+)
+
+/*
+Object matching its keys by their normalized form, while keeping their original spelling.
+
+Implements:
+  - field,
+  - Object.
+*/
+type normalizedObject struct {
+	*object
+	normalize func(string) string
+	keys      map[string]string
+}
+
+/*
+NewNormalizedObject creates a new object matching keys by their normalized form.
+Methods accepting keys (Get, Set, Unset, KeyExists, TypeOf, tree form methods, ...) consider two keys equal
+if the normalizer maps them to the same string. The spelling of a key used first is kept for iteration and serialization.
+Objects created automatically by SetTF are standard objects.
+
+Parameters:
+  - normalizer - function converting a key to its normalized form (e.g. FoldCase, TrimSpace, or a Unicode normalization),
+  - values... - any amount of key-value pairs to set after the object creation.
+
+Returns:
+  - pointer to the created object.
+*/
+func NewNormalizedObject(normalizer func(key string) string, values ...any) Object {
+	ego := &normalizedObject{
+		object:    &object{val: map[string]field{}},
+		normalize: normalizer,
+		keys:      map[string]string{},
+	}
+	ego.Init(ego)
+	ego.Set(values...)
+	return ego
+}
+
+/*
+FoldCase is a key normalizer for case-insensitive matching.
+
+Parameters:
+  - key - key to normalize.
+
+Returns:
+  - normalized key.
+*/
+func FoldCase(key string) string {
+	return strings.ToLower(strings.ToUpper(key))
+}
+
+/*
+TrimSpace is a key normalizer ignoring leading and trailing white space.
+
+Parameters:
+  - key - key to normalize.
+
+Returns:
+  - normalized key.
+*/
+func TrimSpace(key string) string {
+	return strings.TrimSpace(key)
+}
+
+/*
+ChainNormalizers creates a key normalizer applying all given normalizers in order.
+
+Parameters:
+  - normalizers... - any amount of normalizers.
+
+Returns:
+  - created normalizer.
+*/
+func ChainNormalizers(normalizers ...func(string) string) func(string) string {
+	return func(key string) string {
+		for _, normalizer := range normalizers {
+			key = normalizer(key)
+		}
+		return key
+	}
+}
+
+/*
+Finds the stored spelling of a key.
+
+Parameters:
+  - key - key in any spelling.
+
+Returns:
+  - stored spelling of the key (the key itself if the object does not contain it).
+*/
+func (ego *normalizedObject) resolve(key string) string {
+	if stored, exists := ego.keys[ego.normalize(key)]; exists {
+		return stored
+	}
+	return key
+}
+
+/*
+Defined in the field interface.
+Creates a deep copy of the object, keeping the normalizer.
+
+Returns:
+  - copied object.
+*/
+func (ego *normalizedObject) copy() any {
+	result := NewNormalizedObject(ego.normalize)
+	for key, value := range ego.val {
+		result.Set(key, value.copy())
+	}
+	return result
+}
+
+func (ego *normalizedObject) Clone() Object {
+	return ego.copy().(Object)
+}
+
+/*
+Defined in the field interface.
+Checks if the content of the object is equal to the given field.
+Keys of the other object are matched by their normalized form, the same way as when the other object compares itself with this one.
+
+Parameters:
+  - another - value to compare with.
+
+Returns:
+  - true if the objects are equal, false otherwise.
+*/
+func (ego *normalizedObject) isEqual(another any) bool {
+	obj, ok := another.(Object)
+	if !ok || ego.Count() != obj.Count() {
+		return false
+	}
+	equal := true
+	obj.ForEach(func(key string, val any) {
+		equal = equal && ego.KeyExists(key) && parseVal(ego.Get(key)).isEqual(parseVal(val))
+	})
+	return equal
+}
+
+func (ego *normalizedObject) Set(values ...any) Object {
+	length := len(values)
+	if length&1 == 1 {
+		panic("object fields have to be set as key-value pairs")
+	}
+	for i := 0; i < length; i += 2 {
+		key, ok := values[i].(string)
+		if !ok {
+			panic("object key has to be string")
+		}
+		normalized := ego.normalize(key)
+		if stored, exists := ego.keys[normalized]; exists {
+			key = stored
+		} else {
+			ego.keys[normalized] = key
+		}
+		ego.object.Set(key, values[i+1])
+	}
+	return ego.Ego()
+}
+
+func (ego *normalizedObject) Unset(keys ...string) Object {
+	for _, key := range keys {
+		normalized := ego.normalize(key)
+		if stored, exists := ego.keys[normalized]; exists {
+			delete(ego.keys, normalized)
+			ego.object.Unset(stored)
+		}
+	}
+	return ego.Ego()
+}
+
+func (ego *normalizedObject) Clear() Object {
+	ego.keys = map[string]string{}
+	return ego.object.Clear()
+}
+
+func (ego *normalizedObject) Get(key string) any {
+	return ego.object.Get(ego.resolve(key))
+}
+
+func (ego *normalizedObject) TypeOf(key string) Type {
+	return ego.object.TypeOf(ego.resolve(key))
+}
+
+func (ego *normalizedObject) KeyExists(key string) bool {
+	_, exists := ego.keys[ego.normalize(key)]
+	return exists
+}
+
+func (ego *normalizedObject) Omit(keys ...string) Object {
+	omitted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		omitted[ego.normalize(key)] = true
+	}
+	return ego.Ego().PickBy(func(key string, _ any) bool {
+		return !omitted[ego.normalize(key)]
+	})
+}
@@ -0,0 +1,119 @@
+package anytype_test
+
+import (
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestNormalizedObject(t *testing.T) {
+
+	t.Run("keys", func(t *testing.T) {
+		o := anytype.NewNormalizedObject(anytype.FoldCase, "Name", "Alice", "AGE", 30)
+		if o.GetString("name") != "Alice" || o.GetInt("age") != 30 || !o.KeyExists("NAME") || o.KeyExists("city") {
+			t.Error("getting fields by normalized keys does not work properly")
+		}
+		o.Set("name", "Bob")
+		if o.Count() != 2 || o.GetString("Name") != "Bob" || !o.Equals(Object("Name", "Bob", "AGE", 30)) {
+			t.Error("setting fields by normalized keys does not work properly")
+		}
+		if o.TypeOf("nAmE") != anytype.TypeString {
+			t.Error("type check by normalized key does not work properly")
+		}
+		o.Unset("age")
+		if o.KeyExists("AGE") || o.Count() != 1 {
+			t.Error("unsetting fields by normalized keys does not work properly")
+		}
+		o.Set("age", 31)
+		if !o.Equals(Object("Name", "Bob", "age", 31)) {
+			t.Error("original spelling of keys is not preserved")
+		}
+		if !o.Omit("NAME").Equals(Object("age", 31)) {
+			t.Error("omitting fields by normalized keys does not work properly")
+		}
+		o.Clear().Set("AGE", 1)
+		if !o.Equals(Object("AGE", 1)) {
+			t.Error("clearing of the object does not work properly")
+		}
+	})
+
+	t.Run("normalizers", func(t *testing.T) {
+		o := anytype.NewNormalizedObject(anytype.ChainNormalizers(anytype.TrimSpace, anytype.FoldCase), " Key ", 1)
+		if o.GetInt("key") != 1 || !o.KeyExists("  KEY") {
+			t.Error("chained normalizers do not work properly")
+		}
+	})
+
+	t.Run("treeForm", func(t *testing.T) {
+		o := anytype.NewNormalizedObject(anytype.FoldCase,
+			"Inner", anytype.NewNormalizedObject(anytype.FoldCase, "Value", 1),
+			"List", List(2),
+		)
+		if o.GetTF(".inner.value") != 1 || o.GetTF(".LIST#0") != 2 || o.TypeOfTF(".INNER") != anytype.TypeObject {
+			t.Error("tree form getters do not work properly with normalized keys")
+		}
+		o.SetTF(".INNER.VALUE", 3).UnsetTF(".list#0")
+		if !o.Equals(Object("Inner", Object("Value", 3), "List", List())) {
+			t.Error("tree form setters do not work properly with normalized keys")
+		}
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		o := anytype.NewNormalizedObject(anytype.FoldCase, "Key", 1)
+		c := o.Clone()
+		c.Set("KEY", 2)
+		if o.GetInt("key") != 1 || c.GetInt("key") != 2 || c.Count() != 1 {
+			t.Error("cloning of the object does not work properly")
+		}
+		m := o.Merge(Object("other", 3))
+		if m.GetInt("OTHER") != 3 || m.GetInt("KEY") != 1 {
+			t.Error("merging of the object does not work properly")
+		}
+	})
+
+	t.Run("equality", func(t *testing.T) {
+		o := anytype.NewNormalizedObject(anytype.FoldCase, "a", 1, "Nested", Object("x", List(1)))
+		plain := Object("A", 1, "nested", Object("x", List(1)))
+		if !o.Equals(plain) || !plain.Equals(o) {
+			t.Error("equality of normalized and plain objects is not symmetric")
+		}
+		plain.Set("b", 2)
+		if o.Equals(plain) || plain.Equals(o) {
+			t.Error("objects with different keys should not be equal")
+		}
+		if !Object("o", o).Equals(Object("o", Object("A", 1, "NESTED", Object("x", List(1))))) {
+			t.Error("nested normalized objects are not compared properly")
+		}
+	})
+
+	t.Run("parser", func(t *testing.T) {
+		o, err := anytype.ParseObjectWith(`{"Outer":{"Inner":[{"Deep":true}]}}`, anytype.ParserOptions{KeyNormalizer: anytype.FoldCase})
+		if err != nil {
+			t.Fatal("JSON parser failed")
+		}
+		if o.GetTF(".OUTER.INNER#0.DEEP") != true {
+			t.Error("parsing of objects with normalized keys does not work properly")
+		}
+		if o.String() != `{"Outer":{"Inner":[{"Deep":true}]}}` {
+			t.Error("parsed keys do not keep their spelling")
+		}
+		l, err := anytype.ParseListWith(`[{"A":1}]`, anytype.ParserOptions{KeyNormalizer: anytype.FoldCase})
+		if err != nil || l.GetObject(0).GetInt("a") != 1 {
+			t.Error("parsing of lists with normalized keys does not work properly")
+		}
+		if _, err := anytype.ParseObjectWith(`[]`, anytype.ParserOptions{KeyNormalizer: anytype.FoldCase}); err == nil {
+			t.Error("parser options suppress errors")
+		}
+		for _, relaxed := range []bool{false, true} {
+			options := anytype.ParserOptions{KeyNormalizer: anytype.FoldCase, Relaxed: relaxed}
+			for i := 0; i < 20; i++ {
+				o, err := anytype.ParseObjectWith(`{"Content-Type":"a","content-type":"b","nested":{"X":1,"x":2}}`, options)
+				if err != nil || !o.Keys().Sort().Equals(List("Content-Type", "nested")) || o.GetString("content-type") != "b" ||
+					!o.GetObject("nested").Keys().Equals(List("X")) || o.GetTF(".nested.x") != 2 {
+					t.Fatalf("colliding keys are not resolved in the document order: %v", o)
+				}
+			}
+		}
+	})
+
+}
@@ -156,6 +156,8 @@ type Object interface {
 
 	/*
 		String gives a JSON representation of the object, including nested objects and lists.
+		Non-finite floats (e.g. parsed from JSON5 Infinity, TOML inf or YAML .inf) are written as +Inf, -Inf and NaN,
+		so the result is not valid JSON; ToJSON5 can be used to export them.
 
 		Returns:
 		  - JSON string.
@@ -267,6 +269,120 @@ type Object interface {
 	*/
 	Pluck(keys ...string) Object
 
+	/*
+		Omit creates a new object containing all fields of the existing object except the given ones.
+		Keys which do not exist are ignored. The old object remains unchanged.
+
+		Parameters:
+		  - keys... - any amount of keys not to be in the new object.
+
+		Returns:
+		  - new object.
+	*/
+	Omit(keys ...string) Object
+
+	/*
+		PickBy creates a new object containing the fields of the existing object satisfying a condition.
+		The function has two parameters: key of the current field and its value, and returns bool.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	PickBy(function func(key string, val any) bool) Object
+
+	/*
+		OmitBy creates a new object containing the fields of the existing object not satisfying a condition.
+		The function has two parameters: key of the current field and its value, and returns bool.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	OmitBy(function func(key string, val any) bool) Object
+
+	/*
+		FilterValues creates a new object containing the fields of the existing object whose values satisfy a condition.
+		The function has one parameter, value of the current field, and returns bool.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	FilterValues(function func(val any) bool) Object
+
+	/*
+		Rename changes a key of a field. An existing field with the new key is overwritten.
+		If the old key does not exist, nothing happens.
+
+		Parameters:
+		  - oldKey - current key of the field,
+		  - newKey - new key of the field.
+
+		Returns:
+		  - updated object.
+	*/
+	Rename(oldKey string, newKey string) Object
+
+	/*
+		MapKeys creates a new object with the keys changed by a given mapping function.
+		Keys are processed in alphabetical order, if more keys are mapped to the same one, the last field is kept.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	MapKeys(function func(key string) string) Object
+
+	/*
+		MapKeysDeep creates a new object with the keys of the object and all nested objects (including objects in lists)
+		changed by a given mapping function. Collisions are resolved the same way as in MapKeys.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	MapKeysDeep(function func(key string) string) Object
+
+	/*
+		Invert creates a new object with keys and values swapped.
+		All values have to be strings. If more keys have the same value, the alphabetically last key is used.
+		The old object remains unchanged.
+
+		Returns:
+		  - new object.
+	*/
+	Invert() Object
+
+	/*
+		Reduce reduces all fields of the object into a single value.
+		Fields are processed in alphabetical order of their keys.
+		The function has three parameters: accumulator, key of the current field and its value.
+
+		Parameters:
+		  - initial - initial value of the accumulator,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	Reduce(initial any, function func(acc any, key string, val any) any) any
+
 	/*
 		Contains checks if the object contains a field with a given value.
 		Objects and lists are compared by reference.
@@ -291,6 +407,32 @@ type Object interface {
 	*/
 	KeyOf(value any) string
 
+	/*
+		FindKey gives the first key (in alphabetical order) whose field satisfies a condition.
+		The function has two parameters: key of the current field and its value, and returns bool.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - found key (empty string if there is none),
+		  - true if the key has been found, false otherwise.
+	*/
+	FindKey(function func(key string, val any) bool) (string, bool)
+
+	/*
+		FindAll creates a new object containing the fields of the old one satisfying a condition.
+		The function has two parameters: key of the current field and its value, and returns bool.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	FindAll(function func(key string, val any) bool) Object
+
 	/*
 		KeyExists checks if a given key exists within the object.
 
@@ -595,4 +737,127 @@ type Object interface {
 		  - integer constant representing the type (see type enum).
 	*/
 	TypeOfTF(tf string) Type
+
+	/*
+		Walk recursively visits all nested values of the object (depth-first, parents before their children).
+		Keys of objects are visited in alphabetical order.
+		The function has three parameters: tree form path of the current value (relative to the object), the value and its parent,
+		and returns WalkContinue, WalkSkip (children of the value are not visited) or WalkStop (the traversal ends).
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged object.
+	*/
+	Walk(function func(path string, value any, parent any) WalkAction) Object
+
+	/*
+		WalkType recursively visits all nested values of a given type.
+		Values of other types are traversed, but not passed to the function.
+		The function has two parameters: tree form path of the current value and the value, and returns WalkAction (see Walk).
+
+		Parameters:
+		  - t - type of the visited values,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged object.
+	*/
+	WalkType(t Type, function func(path string, value any) WalkAction) Object
+
+	/*
+		Transform creates a new object by recursively applying a function to all nested values (parents before their children).
+		The function has two parameters: tree form path of the current value and the value, and returns the new value.
+		If the new value is an object or a list, its children are transformed as well.
+		The old object remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	Transform(function func(path string, value any) any) Object
+
+	/*
+		TransformType creates a new object by recursively applying a function to all nested values of a given type.
+		Values of other types are kept (their children are transformed).
+		The old object remains unchanged.
+
+		Parameters:
+		  - t - type of the transformed values,
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new object.
+	*/
+	TransformType(t Type, function func(path string, value any) any) Object
+
+	/*
+		Flatten creates a single-level object mapping paths of all leaves of the object to their values.
+		Empty nested objects and lists are considered leaves.
+
+		Parameters:
+		  - separator - string separating the keys and indexes in the paths (empty string means tree form paths).
+
+		Returns:
+		  - new object.
+	*/
+	Flatten(separator string) Object
+
+	/*
+		FlattenWith creates a single-level object mapping paths of all leaves of the object to their values.
+		Empty nested objects and lists are considered leaves.
+
+		Parameters:
+		  - options - flattening options.
+
+		Returns:
+		  - new object.
+	*/
+	FlattenWith(options FlattenOptions) Object
+
+	/*
+		Unflatten rebuilds a nested object from a single-level object created by Flatten.
+		Missing structures are created the same way as by SetTF.
+		Numeric segments of the paths are considered list indexes if they form a sequence 0 to n-1 within their list,
+		other numeric segments are object keys.
+		Causes a panic if any of the paths is not valid.
+
+		Parameters:
+		  - separator - string separating the keys and indexes in the paths (empty string means tree form paths).
+
+		Returns:
+		  - new object.
+	*/
+	Unflatten(separator string) Object
+
+	/*
+		UnflattenWith rebuilds a nested object from a single-level object created by FlattenWith.
+		Missing structures are created the same way as by SetTF.
+		Numeric segments of the paths are considered list indexes, unless Brackets or NumericKeys is set.
+		Lists are created only for indexes forming a sequence 0 to n-1, other indexes become object keys.
+		Causes a panic if any of the paths is not valid.
+
+		Parameters:
+		  - options - flattening options.
+
+		Returns:
+		  - new object.
+	*/
+	UnflattenWith(options FlattenOptions) Object
+
+	/*
+		UnflattenList rebuilds a nested list from a single-level object created by FlattenWith of a list.
+		Missing structures are created the same way as by SetTF.
+		Causes a panic if any of the paths is not valid.
+
+		Parameters:
+		  - options - flattening options.
+
+		Returns:
+		  - new list.
+	*/
+	UnflattenList(options FlattenOptions) List
 }
@@ -158,7 +158,10 @@ Returns:
 */
 func (ego *object) isEqual(another any) bool {
 	obj, ok := another.(*object)
-	if !ok || ego.Ego().Count() != obj.Count() {
+	if !ok {
+		return ego.isEqualDerived(another)
+	}
+	if ego.Ego().Count() != obj.Count() {
 		return false
 	}
 	for k := range ego.val {
@@ -169,6 +172,29 @@ func (ego *object) isEqual(another any) bool {
 	return true
 }
 
+/*
+Checks if the content of the object is equal to a derived structure embedding an object.
+The fields of the derived structure are accessed through the Object interface.
+
+Parameters:
+  - another - value to compare with.
+
+Returns:
+  - true if the contents are equal, false otherwise.
+*/
+func (ego *object) isEqualDerived(another any) bool {
+	obj, ok := another.(Object)
+	if !ok || ego.Ego().Count() != obj.Count() {
+		return false
+	}
+	for k := range ego.val {
+		if !obj.KeyExists(k) || !ego.val[k].isEqual(parseVal(obj.Get(k))) {
+			return false
+		}
+	}
+	return true
+}
+
 func (ego *object) Init(ptr Object) {
 	ego.ptr = ptr
 }
@@ -214,7 +240,7 @@ func (ego *object) Get(key string) any {
 }
 
 func (ego *object) GetObject(key string) Object {
-	o, ok := ego.Get(key).(Object)
+	o, ok := ego.Ego().Get(key).(Object)
 	if !ok {
 		panic(fmt.Sprintf("field '%s' is not an object", key))
 	}
@@ -222,7 +248,7 @@ func (ego *object) GetObject(key string) Object {
 }
 
 func (ego *object) GetList(key string) List {
-	o, ok := ego.Get(key).(List)
+	o, ok := ego.Ego().Get(key).(List)
 	if !ok {
 		panic(fmt.Sprintf("field '%s' is not a list", key))
 	}
@@ -230,7 +256,7 @@ func (ego *object) GetList(key string) List {
 }
 
 func (ego *object) GetString(key string) string {
-	o, ok := ego.Get(key).(string)
+	o, ok := ego.Ego().Get(key).(string)
 	if !ok {
 		panic(fmt.Sprintf("field '%s' is not a string", key))
 	}
@@ -238,7 +264,7 @@ func (ego *object) GetString(key string) string {
 }
 
 func (ego *object) GetBool(key string) bool {
-	o, ok := ego.Get(key).(bool)
+	o, ok := ego.Ego().Get(key).(bool)
 	if !ok {
 		panic(fmt.Sprintf("field '%s' is not a bool", key))
 	}
@@ -246,7 +272,7 @@ func (ego *object) GetBool(key string) bool {
 }
 
 func (ego *object) GetInt(key string) int {
-	o, ok := ego.Get(key).(int)
+	o, ok := ego.Ego().Get(key).(int)
 	if !ok {
 		panic(fmt.Sprintf("field '%s' is not an int", key))
 	}
@@ -254,7 +280,7 @@ func (ego *object) GetInt(key string) int {
 }
 
 func (ego *object) GetFloat(key string) float64 {
-	o, ok := ego.Get(key).(float64)
+	o, ok := ego.Ego().Get(key).(float64)
 	if !ok {
 		panic(fmt.Sprintf("field '%s' is not a float", key))
 	}
@@ -340,7 +366,7 @@ func (ego *object) Equals(another Object) bool {
 }
 
 func (ego *object) Merge(another Object) Object {
-	result := ego.Clone()
+	result := ego.Ego().Clone()
 	another.ForEach(func(key string, val any) {
 		result.Set(key, val)
 	})
@@ -350,7 +376,96 @@ func (ego *object) Merge(another Object) Object {
 func (ego *object) Pluck(keys ...string) Object {
 	result := NewObject()
 	for _, key := range keys {
-		result.Set(key, ego.Get(key))
+		result.Set(key, ego.Ego().Get(key))
+	}
+	return result
+}
+
+func (ego *object) Omit(keys ...string) Object {
+	omitted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		omitted[key] = true
+	}
+	return ego.Ego().PickBy(func(key string, _ any) bool {
+		return !omitted[key]
+	})
+}
+
+func (ego *object) PickBy(function func(string, any) bool) Object {
+	return ego.Ego().FindAll(function)
+}
+
+func (ego *object) OmitBy(function func(string, any) bool) Object {
+	return ego.Ego().FindAll(func(key string, val any) bool {
+		return !function(key, val)
+	})
+}
+
+func (ego *object) FilterValues(function func(any) bool) Object {
+	return ego.Ego().FindAll(func(_ string, val any) bool {
+		return function(val)
+	})
+}
+
+func (ego *object) Rename(oldKey string, newKey string) Object {
+	if oldKey == newKey || !ego.Ego().KeyExists(oldKey) {
+		return ego.Ego()
+	}
+	value := ego.Ego().Get(oldKey)
+	ego.Ego().Unset(oldKey)
+	return ego.Ego().Set(newKey, value)
+}
+
+func (ego *object) MapKeys(function func(string) string) Object {
+	result := NewObject()
+	for _, key := range sortedKeysOf(ego.Ego()) {
+		result.Set(function(key), ego.val[key].getVal())
+	}
+	return result
+}
+
+/*
+Changes keys of all objects nested in a value by a given mapping function.
+
+Parameters:
+  - value - value to process,
+  - function - mapping function.
+
+Returns:
+  - new value.
+*/
+func mapKeysDeep(value any, function func(string) string) any {
+	switch v := value.(type) {
+	case Object:
+		result := NewObject()
+		v.MapKeys(function).ForEach(func(key string, val any) {
+			result.Set(key, mapKeysDeep(val, function))
+		})
+		return result
+	case List:
+		return v.Map(func(_ int, val any) any {
+			return mapKeysDeep(val, function)
+		})
+	}
+	return value
+}
+
+func (ego *object) MapKeysDeep(function func(string) string) Object {
+	return mapKeysDeep(ego.Ego(), function).(Object)
+}
+
+func (ego *object) Invert() Object {
+	result := NewObject()
+	for _, key := range sortedKeysOf(ego.Ego()) {
+		result.Set(ego.Ego().GetString(key), key)
+	}
+	return result
+}
+
+func (ego *object) Reduce(initial any, function func(any, string, any) any) any {
+	result := initial
+	for _, key := range sortedKeysOf(ego.Ego()) {
+		result = function(result, key, ego.val[key].getVal())
 	}
 	return result
 }
@@ -373,6 +488,25 @@ func (ego *object) KeyOf(value any) string {
 	panic(fmt.Sprintf("object does not contain value %v", value))
 }
 
+func (ego *object) FindKey(function func(string, any) bool) (string, bool) {
+	for _, key := range sortedKeysOf(ego.Ego()) {
+		if function(key, ego.val[key].getVal()) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func (ego *object) FindAll(function func(string, any) bool) Object {
+	result := NewObject()
+	for key, item := range ego.val {
+		if function(key, item.getVal()) {
+			result.Set(key, item.getVal())
+		}
+	}
+	return result
+}
+
 func (ego *object) KeyExists(key string) bool {
 	_, ok := ego.val[key]
 	return ok
@@ -594,11 +728,11 @@ func (ego *object) SetTF(tf string, value any) Object {
 	if dot > 0 && (hash < 0 || dot < hash) {
 		key := tf[:dot]
 		var object Object
-		if ego.TypeOf(key) == TypeObject {
-			object = ego.GetObject(key)
+		if ego.Ego().TypeOf(key) == TypeObject {
+			object = ego.Ego().GetObject(key)
 		} else {
 			object = NewObject()
-			ego.Set(key, object)
+			ego.Ego().Set(key, object)
 		}
 		object.SetTF(tf[dot:], value)
 		return ego.Ego()
@@ -606,11 +740,11 @@ func (ego *object) SetTF(tf string, value any) Object {
 	if hash > 0 && (dot < 0 || hash < dot) {
 		key := tf[:hash]
 		var list List
-		if ego.KeyExists(key) {
-			list = ego.GetList(key)
+		if ego.Ego().KeyExists(key) {
+			list = ego.Ego().GetList(key)
 		} else {
 			list = NewList()
-			ego.Set(key, list)
+			ego.Ego().Set(key, list)
 		}
 		list.SetTF(tf[hash:], value)
 		return ego.Ego()
@@ -627,13 +761,13 @@ func (ego *object) UnsetTF(tf string) Object {
 	hash := strings.Index(tf, "#")
 	if dot > 0 && (hash < 0 || dot < hash) {
 		key := tf[:dot]
-		object := ego.GetObject(key)
+		object := ego.Ego().GetObject(key)
 		object.UnsetTF(tf[dot:])
 		return ego.Ego()
 	}
 	if hash > 0 && (dot < 0 || hash < dot) {
 		key := tf[:hash]
-		list := ego.GetList(key)
+		list := ego.Ego().GetList(key)
 		list.UnsetTF(tf[hash:])
 		return ego.Ego()
 	}
@@ -666,3 +800,73 @@ func (ego *object) TypeOfTF(tf string) Type {
 	}
 	return ego.Ego().TypeOf(tf)
 }
+
+func (ego *object) Flatten(separator string) Object {
+	return ego.Ego().FlattenWith(FlattenOptions{Separator: separator})
+}
+
+func (ego *object) FlattenWith(options FlattenOptions) Object {
+	result := NewObject()
+	flattenValue(result, options, "", 0, ego.Ego())
+	return result
+}
+
+func (ego *object) Unflatten(separator string) Object {
+	return ego.Ego().UnflattenWith(FlattenOptions{Separator: separator})
+}
+
+func (ego *object) UnflattenWith(options FlattenOptions) Object {
+	result := NewObject()
+	tfs, values := ego.treeForms(options)
+	for i, tf := range tfs {
+		result.SetTF(tf, values[i])
+	}
+	return result
+}
+
+func (ego *object) UnflattenList(options FlattenOptions) List {
+	result := NewList()
+	tfs, values := ego.treeForms(options)
+	for i, tf := range tfs {
+		result.SetTF(tf, values[i])
+	}
+	return result
+}
+
+/*
+Converts the paths of a flat object to tree forms.
+Only dense list indexes (0 to n-1) are kept, other indexes are converted to object keys.
+
+Parameters:
+  - options - flattening options.
+
+Returns:
+  - tree forms of the fields,
+  - values of the fields in the same order.
+*/
+func (ego *object) treeForms(options FlattenOptions) ([]string, []any) {
+	tfs := make([]string, 0, len(ego.val))
+	values := make([]any, 0, len(ego.val))
+	for key, item := range ego.val {
+		tfs = append(tfs, options.treeForm(key))
+		values = append(values, item.getVal())
+	}
+	return denseIndexes(tfs), values
+}
+
+func (ego *object) Walk(function func(string, any, any) WalkAction) Object {
+	walkChildren("", ego.Ego(), function)
+	return ego.Ego()
+}
+
+func (ego *object) WalkType(t Type, function func(string, any) WalkAction) Object {
+	return ego.Ego().Walk(walkType(t, function))
+}
+
+func (ego *object) Transform(function func(string, any) any) Object {
+	return transformChildren("", ego.Ego(), function).(Object)
+}
+
+func (ego *object) TransformType(t Type, function func(string, any) any) Object {
+	return ego.Ego().Transform(transformType(t, function))
+}
@@ -1,6 +1,8 @@
 package anytype_test
 
 import (
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
@@ -284,6 +286,205 @@ func TestObject(t *testing.T) {
 		}
 	})
 
+	t.Run("flattening", func(t *testing.T) {
+		o := Object(
+			"db", Object("host", "localhost", "ports", List(5432, 5433)),
+			"tags", List(Object("name", "a"), List(true)),
+			"empty", Object(),
+			"debug", false,
+		)
+		flat := o.Flatten("")
+		if !flat.Equals(Object(
+			".db.host", "localhost",
+			".db.ports#0", 5432,
+			".db.ports#1", 5433,
+			".tags#0.name", "a",
+			".tags#1#0", true,
+			".empty", Object(),
+			".debug", false,
+		)) {
+			t.Error("flattening to tree forms does not work properly")
+		}
+		if !flat.Unflatten("").Equals(o) {
+			t.Error("unflattening from tree forms does not work properly")
+		}
+		env := o.Flatten("__")
+		if env.GetInt("db__ports__1") != 5433 || env.GetBool("tags__1__0") != true || !env.Unflatten("__").Equals(o) {
+			t.Error("flattening with a separator does not work properly")
+		}
+		options := anytype.FlattenOptions{Separator: ".", Brackets: true}
+		brackets := o.FlattenWith(options)
+		if brackets.GetInt("db.ports[0]") != 5432 || brackets.GetBool("tags[1][0]") != true || !brackets.UnflattenWith(options).Equals(o) {
+			t.Error("flattening with brackets does not work properly")
+		}
+		if !Object("2024", Object("total", 1)).FlattenWith(options).UnflattenWith(options).Equals(Object("2024", Object("total", 1))) {
+			t.Error("numeric keys should be preserved with brackets")
+		}
+		years := Object("years", Object("0", "zero", "2024", Object("total", 1)))
+		keys := anytype.FlattenOptions{Separator: ".", NumericKeys: true}
+		if !years.FlattenWith(keys).UnflattenWith(keys).Equals(years) {
+			t.Error("numeric keys should be preserved with NumericKeys")
+		}
+		if !years.Flatten(".").Unflatten(".").Equals(years) {
+			t.Error("sparse numeric segments should be kept as object keys")
+		}
+		if !Object("a.999999999", 1, "b.1", 2).Unflatten(".").Equals(Object("a", Object("999999999", 1), "b", Object("1", 2))) ||
+			!Object(".c#2", 3, ".d#1", 4, ".d#0", 5).Unflatten("").Equals(Object("c", Object("2", 3), "d", List(5, 4))) {
+			t.Error("lists with sparse indexes should not be created")
+		}
+		shallow := o.FlattenWith(anytype.FlattenOptions{Separator: ".", MaxDepth: 2})
+		if !shallow.GetList("db.ports").Equals(List(5432, 5433)) || !shallow.GetObject("tags.0").Equals(Object("name", "a")) || shallow.Count() != 6 {
+			t.Error("flattening with maximal depth does not work properly")
+		}
+		l := List(Object("a", 1), List(2))
+		if !l.FlattenWith(anytype.FlattenOptions{}).Equals(Object("#0.a", 1, "#1#0", 2)) {
+			t.Error("flattening of list does not work properly")
+		}
+		for _, options := range []anytype.FlattenOptions{{}, {Separator: "/"}, {Separator: "/", Brackets: true}} {
+			if !l.FlattenWith(options).UnflattenList(options).Equals(l) {
+				t.Error("unflattening of list does not work properly")
+			}
+		}
+	})
+
+	t.Run("walk", func(t *testing.T) {
+		o := Object(
+			"user", Object("name", "John", "password", "secret", "age", 30),
+			"items", List(Object("id", 1), 2),
+			"active", true,
+		)
+		var paths []string
+		o.Walk(func(path string, value any, parent any) anytype.WalkAction {
+			paths = append(paths, path)
+			return anytype.WalkContinue
+		})
+		expected := []string{".active", ".items", ".items#0", ".items#0.id", ".items#1", ".user", ".user.age", ".user.name", ".user.password"}
+		if len(paths) != len(expected) {
+			t.Fatal("Walk does not visit all values")
+		}
+		for i := range expected {
+			if paths[i] != expected[i] {
+				t.Errorf("value %d is not visited in proper order", i)
+			}
+		}
+		paths = nil
+		o.Walk(func(path string, value any, parent any) anytype.WalkAction {
+			paths = append(paths, path)
+			if path == ".items" {
+				return anytype.WalkSkip
+			}
+			if path == ".user.age" {
+				if parent.(anytype.Object).GetString("name") != "John" {
+					t.Error("Walk does not pass the parent")
+				}
+				return anytype.WalkStop
+			}
+			return anytype.WalkContinue
+		})
+		if len(paths) != 4 || paths[2] != ".user" {
+			t.Error("skipping or stopping does not work properly")
+		}
+		count := 0
+		o.WalkType(anytype.TypeInt, func(path string, value any) anytype.WalkAction {
+			count += value.(int)
+			return anytype.WalkContinue
+		})
+		if count != 33 {
+			t.Error("WalkType does not work properly")
+		}
+		redacted := o.Transform(func(path string, value any) any {
+			if path == ".user.password" {
+				return "***"
+			}
+			if obj, ok := value.(anytype.Object); ok && obj.KeyExists("id") {
+				return Object("identifier", obj.Get("id"))
+			}
+			return value
+		})
+		if redacted.GetTF(".user.password") != "***" || redacted.GetTF(".items#0.identifier") != 1 || o.GetTF(".user.password") != "secret" {
+			t.Error("Transform does not work properly")
+		}
+		stringified := o.TransformType(anytype.TypeInt, func(path string, value any) any {
+			return strconv.Itoa(value.(int))
+		})
+		if !stringified.Equals(Object(
+			"user", Object("name", "John", "password", "secret", "age", "30"),
+			"items", List(Object("id", "1"), "2"),
+			"active", true,
+		)) {
+			t.Error("TransformType does not work properly")
+		}
+		if !List(1, List(2)).TransformType(anytype.TypeInt, func(path string, value any) any {
+			return path
+		}).Equals(List("#0", List("#1#0"))) {
+			t.Error("Transform of list does not work properly")
+		}
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		o := Object("first_name", "John", "last_name", "Doe", "age", 30, "address", Object("zip_code", "12345"))
+		if !o.Omit("age", "address", "missing").Equals(Object("first_name", "John", "last_name", "Doe")) {
+			t.Error("Omit does not work properly")
+		}
+		isString := func(key string, value any) bool {
+			_, ok := value.(string)
+			return ok
+		}
+		if !o.PickBy(isString).Equals(Object("first_name", "John", "last_name", "Doe")) || !o.OmitBy(isString).Equals(Object("age", 30, "address", Object("zip_code", "12345"))) {
+			t.Error("PickBy or OmitBy does not work properly")
+		}
+		if !o.FilterValues(func(value any) bool { return value == 30 }).Equals(Object("age", 30)) {
+			t.Error("FilterValues does not work properly")
+		}
+		camel := func(key string) string {
+			parts := strings.Split(key, "_")
+			for i := 1; i < len(parts); i++ {
+				parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+			}
+			return strings.Join(parts, "")
+		}
+		if !o.MapKeys(camel).Equals(Object("firstName", "John", "lastName", "Doe", "age", 30, "address", Object("zip_code", "12345"))) {
+			t.Error("MapKeys does not work properly")
+		}
+		deep := Object("user_data", List(Object("zip_code", 1), 2)).MapKeysDeep(camel)
+		if !deep.Equals(Object("userData", List(Object("zipCode", 1), 2))) {
+			t.Error("MapKeysDeep does not work properly")
+		}
+		if !Object("a", 1, "b", 2).MapKeys(func(string) string { return "x" }).Equals(Object("x", 2)) {
+			t.Error("MapKeys does not resolve collisions properly")
+		}
+		if !Object("a", "x", "b", "y", "c", "x").Invert().Equals(Object("x", "c", "y", "b")) {
+			t.Error("Invert does not work properly")
+		}
+		sum := Object("a", 1, "b", 2, "c", 3).Reduce("", func(acc any, key string, value any) any {
+			return acc.(string) + key + strconv.Itoa(value.(int))
+		})
+		if sum != "a1b2c3" {
+			t.Error("Reduce does not work properly")
+		}
+		o.Rename("first_name", "name").Rename("missing", "other").Rename("age", "last_name")
+		if !o.Equals(Object("name", "John", "last_name", 30, "address", Object("zip_code", "12345"))) {
+			t.Error("Rename does not work properly")
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		o := Object("b", 2, "a", 1, "c", 3, "text", "test")
+		isInt := func(key string, value any) bool {
+			_, ok := value.(int)
+			return ok
+		}
+		if key, ok := o.FindKey(isInt); !ok || key != "a" {
+			t.Error("FindKey does not work properly")
+		}
+		if _, ok := o.FindKey(func(key string, value any) bool { return false }); ok {
+			t.Error("FindKey should not find anything")
+		}
+		if !o.FindAll(isInt).Equals(Object("a", 1, "b", 2, "c", 3)) || !Object().FindAll(isInt).Empty() {
+			t.Error("FindAll does not work properly")
+		}
+	})
+
 }
 
 func TestObjectPanics(t *testing.T) {
@@ -354,6 +555,16 @@ func TestObjectPanics(t *testing.T) {
 		Object().KeyOf("test")
 	})
 
+	t.Run("invalidUnflatten", func(t *testing.T) {
+		defer catch("unflattening invalid path did not cause panic")
+		Object("a[x]", 1).UnflattenWith(anytype.FlattenOptions{Separator: ".", Brackets: true})
+	})
+
+	t.Run("invalidInvert", func(t *testing.T) {
+		defer catch("inverting non-string value did not cause panic")
+		Object("a", 1).Invert()
+	})
+
 	t.Run("invalidGetTF", func(t *testing.T) {
 		defer catch("getting invalid tree form did not cause panic")
 		Object().GetTF("")
@@ -0,0 +1,973 @@
+/*
+AnyType Library for Go
+Observable object and list (change notifications)
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/*
+Operation is an enum of changes reported by observable objects and lists.
+*/
+type Operation uint8
+
+const (
+	OpAdd Operation = iota
+	OpInsert
+	OpReplace
+	OpDelete
+	OpClear
+	OpSort
+	OpReverse
+	OpMove
+)
+
+/*
+Event describes a single change of an observed structure.
+The meaning of the values depends on the operation:
+  - OpAdd - a new field was set or an element was appended, New contains the value,
+  - OpInsert - an element was inserted into a list, New contains the value,
+  - OpReplace - an existing field or element was overwritten, Old and New contain both values,
+  - OpDelete - a field was unset or an element was deleted, Old contains the removed value,
+  - OpClear - the structure was cleared, Old contains a copy of its former content,
+  - OpSort, OpReverse, OpMove - elements of the list were reordered (sorted, reversed, or moved by Swap, Move, Rotate or Shuffle),
+    Old and New contain copies of the former and the current order.
+
+Objects and lists in Old and New are deep copies made at the moment of the change, so later changes
+(including the ones delivered in the same batch) do not affect them.
+
+Fields:
+  - Path - tree form of the changed field relative to the root of the observed structure (empty string for the root itself),
+  - Op - performed operation,
+  - Old - previous value,
+  - New - current value.
+*/
+type Event struct {
+	Path string
+	Op   Operation
+	Old  any
+	New  any
+}
+
+/*
+Subscription of a handler to events under a certain path.
+*/
+type subscriber struct {
+	prefix  string
+	handler func([]Event)
+}
+
+/*
+Consumer of events which has to process them immediately, even during a batch.
+*/
+type recorder interface {
+
+	/*
+		Processes a single event at the moment of the change.
+
+		Parameters:
+		  - event - the event.
+	*/
+	record(event Event)
+
+	/*
+		Marks the end of a change or a batch, so the recorded events can be grouped.
+	*/
+	commit()
+}
+
+/*
+Shared state of an observed structure.
+Holds the subscribers, the recorders and the events postponed by a running batch.
+*/
+type observer struct {
+	mutex       sync.Mutex
+	subscribers map[int]subscriber
+	recorders   []recorder
+	next        int
+	batch       int
+	pending     []Event
+}
+
+/*
+Creates a new observer with no subscribers.
+
+Returns:
+  - pointer to the created observer.
+*/
+func newObserver() *observer {
+	return &observer{subscribers: map[int]subscriber{}}
+}
+
+/*
+Registers a new handler.
+
+Parameters:
+  - prefix - tree form of the observed subtree,
+  - handler - function receiving the events.
+
+Returns:
+  - ID of the subscription.
+*/
+func (ego *observer) subscribe(prefix string, handler func([]Event)) int {
+	ego.mutex.Lock()
+	defer ego.mutex.Unlock()
+	ego.next++
+	ego.subscribers[ego.next] = subscriber{prefix: prefix, handler: handler}
+	return ego.next
+}
+
+/*
+Removes a handler. If the ID does not exist, nothing happens.
+
+Parameters:
+  - id - ID of the subscription.
+*/
+func (ego *observer) unsubscribe(id int) {
+	ego.mutex.Lock()
+	defer ego.mutex.Unlock()
+	delete(ego.subscribers, id)
+}
+
+/*
+Registers a new recorder.
+
+Parameters:
+  - rec - recorder to register.
+*/
+func (ego *observer) addRecorder(rec recorder) {
+	ego.mutex.Lock()
+	defer ego.mutex.Unlock()
+	ego.recorders = append(ego.recorders, rec)
+}
+
+/*
+Removes a recorder. If it is not registered, nothing happens.
+
+Parameters:
+  - rec - recorder to remove.
+*/
+func (ego *observer) removeRecorder(rec recorder) {
+	ego.mutex.Lock()
+	defer ego.mutex.Unlock()
+	for i, r := range ego.recorders {
+		if r == rec {
+			ego.recorders = append(ego.recorders[:i], ego.recorders[i+1:]...)
+			return
+		}
+	}
+}
+
+/*
+Creates a deep copy of an object or a list, so it is not affected by subsequent changes.
+Values of other types are returned unchanged.
+
+Parameters:
+  - value - value to copy.
+
+Returns:
+  - copied value.
+*/
+func snapshot(value any) any {
+	switch v := value.(type) {
+	case Object:
+		return v.Clone()
+	case List:
+		return v.Clone()
+	}
+	return value
+}
+
+/*
+Passes an event to the recorders and delivers it immediately, or postpones it if a batch is running.
+Values of the event are copied, so they describe the state at the moment of the change.
+
+Parameters:
+  - event - event to deliver.
+*/
+func (ego *observer) emit(event Event) {
+	event.Old, event.New = snapshot(event.Old), snapshot(event.New)
+	ego.mutex.Lock()
+	batch := ego.batch > 0
+	if batch {
+		ego.pending = append(ego.pending, event)
+	}
+	recorders := append([]recorder{}, ego.recorders...)
+	ego.mutex.Unlock()
+	for _, rec := range recorders {
+		rec.record(event)
+	}
+	if !batch {
+		for _, rec := range recorders {
+			rec.commit()
+		}
+		ego.deliver([]Event{event})
+	}
+}
+
+/*
+Executes a function while postponing all events.
+The events are delivered together after the outermost batch ends (even if the function panics).
+
+Parameters:
+  - function - function to execute.
+*/
+func (ego *observer) run(function func()) {
+	ego.mutex.Lock()
+	ego.batch++
+	ego.mutex.Unlock()
+	defer func() {
+		ego.mutex.Lock()
+		ego.batch--
+		var events []Event
+		var recorders []recorder
+		if ego.batch == 0 {
+			events = ego.pending
+			ego.pending = nil
+			recorders = append(recorders, ego.recorders...)
+		}
+		ego.mutex.Unlock()
+		for _, rec := range recorders {
+			rec.commit()
+		}
+		if len(events) > 0 {
+			ego.deliver(events)
+		}
+	}()
+	function()
+}
+
+/*
+Acquires the number of events postponed by the running batch.
+
+Returns:
+  - number of pending events.
+*/
+func (ego *observer) position() int {
+	ego.mutex.Lock()
+	defer ego.mutex.Unlock()
+	return len(ego.pending)
+}
+
+/*
+Drops the events postponed after a given position, so they are never delivered.
+
+Parameters:
+  - position - number of pending events to keep.
+*/
+func (ego *observer) discard(position int) {
+	ego.mutex.Lock()
+	defer ego.mutex.Unlock()
+	if position < len(ego.pending) {
+		ego.pending = ego.pending[:position]
+	}
+}
+
+/*
+Passes the events to all subscribers whose prefix matches.
+Handlers are called in the order of subscription.
+
+Parameters:
+  - events - events to deliver.
+*/
+func (ego *observer) deliver(events []Event) {
+	ego.mutex.Lock()
+	ids := make([]int, 0, len(ego.subscribers))
+	for id := range ego.subscribers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subscribers := make([]subscriber, len(ids))
+	for i, id := range ids {
+		subscribers[i] = ego.subscribers[id]
+	}
+	ego.mutex.Unlock()
+	for _, sub := range subscribers {
+		selected := make([]Event, 0, len(events))
+		for _, event := range events {
+			if pathsOverlap(sub.prefix, event.Path) {
+				selected = append(selected, event)
+			}
+		}
+		if len(selected) > 0 {
+			sub.handler(selected)
+		}
+	}
+}
+
+/*
+Checks whether one tree form path is equal to another or lies inside the subtree of the other.
+
+Parameters:
+  - first - first path,
+  - second - second path.
+
+Returns:
+  - true if one path is a prefix of the other on a segment boundary, false otherwise.
+*/
+func pathsOverlap(first string, second string) bool {
+	if len(first) > len(second) {
+		first, second = second, first
+	}
+	if !strings.HasPrefix(second, first) {
+		return false
+	}
+	return len(first) == len(second) || second[len(first)] == '.' || second[len(first)] == '#'
+}
+
+/*
+Wraps a nested object or list into an observable structure sharing the given observer.
+Structures which are already observed are only reattached to the new parent.
+Values of other types (including custom derived structures) are returned unchanged.
+
+Parameters:
+  - value - value to wrap,
+  - parent - observable structure containing the value,
+  - hub - shared observer.
+
+Returns:
+  - wrapped value.
+*/
+func observe(value any, parent any, hub *observer) any {
+	switch v := value.(type) {
+	case *object:
+		ego := &ObservableObject{Object: v, hub: hub, parent: parent}
+		ego.Init(ego)
+		ego.observeChildren()
+		return ego
+	case *list:
+		ego := &ObservableList{List: v, hub: hub, parent: parent}
+		ego.Init(ego)
+		ego.observeChildren()
+		return ego
+	case *ObservableObject:
+		foreign := v.hub != hub
+		v.hub, v.parent = hub, parent
+		if foreign {
+			v.observeChildren()
+		}
+	case *ObservableList:
+		foreign := v.hub != hub
+		v.hub, v.parent = hub, parent
+		if foreign {
+			v.observeChildren()
+		}
+	}
+	return value
+}
+
+/*
+Computes a tree form path of an observed structure.
+
+Parameters:
+  - node - the observed structure,
+  - parent - its parent (nil for the root).
+
+Returns:
+  - tree form path,
+  - false if the structure is no longer attached to the observed tree.
+*/
+func observedPath(node any, parent any) (string, bool) {
+	switch p := parent.(type) {
+	case *ObservableObject:
+		base, ok := p.path()
+		if !ok {
+			return "", false
+		}
+		var key string
+		found := false
+		p.Object.ForEach(func(k string, v any) {
+			if !found && v == node {
+				key, found = k, true
+			}
+		})
+		return base + "." + key, found
+	case *ObservableList:
+		base, ok := p.path()
+		if !ok {
+			return "", false
+		}
+		for i := 0; i < p.List.Count(); i++ {
+			if p.List.Get(i) == node {
+				return base + "#" + strconv.Itoa(i), true
+			}
+		}
+		return "", false
+	default:
+		return "", true
+	}
+}
+
+/*
+Sets a value specified by a tree form, creating missing nested structures.
+All changes are performed through the interface methods, so the derived structures on the path are notified.
+
+Parameters:
+  - container - object or list to start from,
+  - tf - tree form string,
+  - value - value to set.
+*/
+func setTF(container any, tf string, value any) {
+	validateTF(container, tf)
+	segments := splitTF(tf)
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch c := container.(type) {
+		case Object:
+			key := segment[1:]
+			if last {
+				c.Set(key, value)
+				return
+			}
+			if segments[i+1][0] == '.' {
+				if c.TypeOf(key) != TypeObject {
+					c.Set(key, NewObject())
+				}
+				container = c.GetObject(key)
+			} else {
+				if !c.KeyExists(key) {
+					c.Set(key, NewList())
+				}
+				container = c.GetList(key)
+			}
+		case List:
+			index := parseIndex(segment[1:])
+			count := c.Count()
+			if index >= count {
+				for j := 0; j < index-count; j++ {
+					c.Add(nil)
+				}
+			}
+			if last {
+				if index >= count {
+					c.Add(value)
+				} else {
+					c.Replace(index, value)
+				}
+				return
+			}
+			if segments[i+1][0] == '.' {
+				if index >= count {
+					c.Add(NewObject())
+				} else if c.TypeOf(index) != TypeObject {
+					c.Replace(index, NewObject())
+				}
+				container = c.GetObject(index)
+			} else {
+				if index >= count {
+					c.Add(NewList())
+				} else if c.TypeOf(index) != TypeList {
+					c.Replace(index, NewList())
+				}
+				container = c.GetList(index)
+			}
+		}
+	}
+}
+
+/*
+Deletes a value specified by a tree form.
+All changes are performed through the interface methods, so the derived structures on the path are notified.
+
+Parameters:
+  - container - object or list to start from,
+  - tf - tree form string.
+*/
+func unsetTF(container any, tf string) {
+	validateTF(container, tf)
+	segments := splitTF(tf)
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch c := container.(type) {
+		case Object:
+			key := segment[1:]
+			if last {
+				c.Unset(key)
+			} else if segments[i+1][0] == '.' {
+				container = c.GetObject(key)
+			} else {
+				container = c.GetList(key)
+			}
+		case List:
+			index := parseIndex(segment[1:])
+			if last {
+				c.Delete(index)
+			} else if segments[i+1][0] == '.' {
+				container = c.GetObject(index)
+			} else {
+				container = c.GetList(index)
+			}
+		}
+	}
+}
+
+/*
+Checks whether a tree form can be applied to a given container.
+Causes a panic if not.
+
+Parameters:
+  - container - object or list,
+  - tf - tree form string.
+*/
+func validateTF(container any, tf string) {
+	if _, ok := container.(Object); ok && (len(tf) < 2 || tf[0] != '.') {
+		panic(fmt.Sprintf("'%s' is not a valid tree form for an object", tf))
+	}
+	if _, ok := container.(List); ok && (len(tf) < 2 || tf[0] != '#') {
+		panic(fmt.Sprintf("'%s' is not a valid tree form for a list", tf))
+	}
+}
+
+/*
+ObservableObject is an object notifying subscribers about its changes.
+Objects and lists nested in it become observable as well, so changes made directly on them are reported too.
+
+Extends:
+  - Object.
+*/
+type ObservableObject struct {
+	Object
+	hub    *observer
+	parent any
+}
+
+/*
+NewObservableObject makes an existing object observable.
+The object should not be changed through the original reference afterwards, otherwise the changes are not reported.
+
+Parameters:
+  - obj - object to observe.
+
+Returns:
+  - pointer to the created observable object.
+*/
+func NewObservableObject(obj Object) *ObservableObject {
+	ego := &ObservableObject{Object: obj, hub: newObserver()}
+	ego.Init(ego)
+	ego.observeChildren()
+	return ego
+}
+
+/*
+Wraps all nested structures of the object.
+*/
+func (ego *ObservableObject) observeChildren() {
+	for _, key := range ego.Object.Keys().StringSlice() {
+		observe(ego.Object.Get(key), ego, ego.hub)
+	}
+}
+
+/*
+Computes a tree form path of the object within the observed tree.
+
+Returns:
+  - tree form path,
+  - false if the object is no longer attached to the observed tree.
+*/
+func (ego *ObservableObject) path() (string, bool) {
+	return observedPath(ego, ego.parent)
+}
+
+/*
+Emits an event if the object is attached to the observed tree.
+
+Parameters:
+  - suffix - tree form of the change relative to the object,
+  - op - performed operation,
+  - old - previous value,
+  - new - current value.
+*/
+func (ego *ObservableObject) emit(suffix string, op Operation, old any, new any) {
+	if path, ok := ego.path(); ok {
+		ego.hub.emit(Event{Path: path + suffix, Op: op, Old: old, New: new})
+	}
+}
+
+/*
+Subscribe registers a handler receiving the events.
+Only events whose path lies inside the given subtree, or whose path contains the subtree, are passed.
+The handler receives a single event per change, or all events of a batch at once.
+
+Parameters:
+  - prefix - tree form relative to the root of the observed structure (empty string for all events),
+  - handler - function receiving the events.
+
+Returns:
+  - ID of the subscription.
+*/
+func (ego *ObservableObject) Subscribe(prefix string, handler func(events []Event)) int {
+	return ego.hub.subscribe(prefix, handler)
+}
+
+/*
+Unsubscribe removes a previously registered handler. If the ID does not exist, nothing happens.
+
+Parameters:
+  - id - ID of the subscription.
+*/
+func (ego *ObservableObject) Unsubscribe(id int) {
+	ego.hub.unsubscribe(id)
+}
+
+/*
+Batch executes a given function and delivers all events it caused together after it ends.
+
+Parameters:
+  - function - function to execute.
+
+Returns:
+  - unchanged object.
+*/
+func (ego *ObservableObject) Batch(function func()) Object {
+	ego.hub.run(function)
+	return ego.Ego()
+}
+
+func (ego *ObservableObject) Set(values ...any) Object {
+	length := len(values)
+	if length&1 == 1 {
+		panic("object fields have to be set as key-value pairs")
+	}
+	ego.hub.run(func() {
+		for i := 0; i < length; i += 2 {
+			key, ok := values[i].(string)
+			if !ok {
+				panic("object key has to be string")
+			}
+			existed := ego.Object.KeyExists(key)
+			var old any
+			if existed {
+				old = ego.Object.Get(key)
+			}
+			ego.Object.Set(key, values[i+1])
+			value := observe(ego.Object.Get(key), ego, ego.hub)
+			if existed {
+				ego.emit("."+key, OpReplace, old, value)
+			} else {
+				ego.emit("."+key, OpAdd, nil, value)
+			}
+		}
+	})
+	return ego.Ego()
+}
+
+func (ego *ObservableObject) Rename(oldKey string, newKey string) Object {
+	ego.hub.run(func() { ego.Object.Rename(oldKey, newKey) })
+	return ego.Ego()
+}
+
+func (ego *ObservableObject) Unset(keys ...string) Object {
+	ego.hub.run(func() {
+		for _, key := range keys {
+			if !ego.Object.KeyExists(key) {
+				continue
+			}
+			old := ego.Object.Get(key)
+			ego.Object.Unset(key)
+			ego.emit("."+key, OpDelete, old, nil)
+		}
+	})
+	return ego.Ego()
+}
+
+func (ego *ObservableObject) Clear() Object {
+	if ego.Object.Empty() {
+		return ego.Ego()
+	}
+	old := NewObjectFrom(ego.Object.Dict())
+	ego.Object.Clear()
+	ego.emit("", OpClear, old, nil)
+	return ego.Ego()
+}
+
+func (ego *ObservableObject) SetTF(tf string, value any) Object {
+	ego.hub.run(func() {
+		setTF(ego.Ego(), tf, value)
+	})
+	return ego.Ego()
+}
+
+func (ego *ObservableObject) UnsetTF(tf string) Object {
+	ego.hub.run(func() {
+		unsetTF(ego.Ego(), tf)
+	})
+	return ego.Ego()
+}
+
+/*
+ObservableList is a list notifying subscribers about its changes.
+Objects and lists nested in it become observable as well, so changes made directly on them are reported too.
+
+Extends:
+  - List.
+*/
+type ObservableList struct {
+	List
+	hub    *observer
+	parent any
+}
+
+/*
+NewObservableList makes an existing list observable.
+The list should not be changed through the original reference afterwards, otherwise the changes are not reported.
+
+Parameters:
+  - list - list to observe.
+
+Returns:
+  - pointer to the created observable list.
+*/
+func NewObservableList(list List) *ObservableList {
+	ego := &ObservableList{List: list, hub: newObserver()}
+	ego.Init(ego)
+	ego.observeChildren()
+	return ego
+}
+
+/*
+Wraps all nested structures of the list.
+*/
+func (ego *ObservableList) observeChildren() {
+	for i := 0; i < ego.List.Count(); i++ {
+		observe(ego.List.Get(i), ego, ego.hub)
+	}
+}
+
+/*
+Computes a tree form path of the list within the observed tree.
+
+Returns:
+  - tree form path,
+  - false if the list is no longer attached to the observed tree.
+*/
+func (ego *ObservableList) path() (string, bool) {
+	return observedPath(ego, ego.parent)
+}
+
+/*
+Emits an event if the list is attached to the observed tree.
+
+Parameters:
+  - suffix - tree form of the change relative to the list,
+  - op - performed operation,
+  - old - previous value,
+  - new - current value.
+*/
+func (ego *ObservableList) emit(suffix string, op Operation, old any, new any) {
+	if path, ok := ego.path(); ok {
+		ego.hub.emit(Event{Path: path + suffix, Op: op, Old: old, New: new})
+	}
+}
+
+/*
+Emits an event with a given index.
+
+Parameters:
+  - index - position of the changed element,
+  - op - performed operation,
+  - old - previous value,
+  - new - current value.
+*/
+func (ego *ObservableList) emitAt(index int, op Operation, old any, new any) {
+	ego.emit("#"+strconv.Itoa(index), op, old, new)
+}
+
+/*
+Subscribe registers a handler receiving the events.
+Only events whose path lies inside the given subtree, or whose path contains the subtree, are passed.
+The handler receives a single event per change, or all events of a batch at once.
+
+Parameters:
+  - prefix - tree form relative to the root of the observed structure (empty string for all events),
+  - handler - function receiving the events.
+
+Returns:
+  - ID of the subscription.
+*/
+func (ego *ObservableList) Subscribe(prefix string, handler func(events []Event)) int {
+	return ego.hub.subscribe(prefix, handler)
+}
+
+/*
+Unsubscribe removes a previously registered handler. If the ID does not exist, nothing happens.
+
+Parameters:
+  - id - ID of the subscription.
+*/
+func (ego *ObservableList) Unsubscribe(id int) {
+	ego.hub.unsubscribe(id)
+}
+
+/*
+Batch executes a given function and delivers all events it caused together after it ends.
+
+Parameters:
+  - function - function to execute.
+
+Returns:
+  - unchanged list.
+*/
+func (ego *ObservableList) Batch(function func()) List {
+	ego.hub.run(function)
+	return ego.Ego()
+}
+
+func (ego *ObservableList) Add(values ...any) List {
+	ego.hub.run(func() {
+		for _, val := range values {
+			ego.List.Add(val)
+			index := ego.List.Count() - 1
+			ego.emitAt(index, OpAdd, nil, observe(ego.List.Get(index), ego, ego.hub))
+		}
+	})
+	return ego.Ego()
+}
+
+func (ego *ObservableList) Insert(index int, value any) List {
+	if index == ego.List.Count() {
+		return ego.Add(value)
+	}
+	ego.List.Insert(index, value)
+	ego.emitAt(index, OpInsert, nil, observe(ego.List.Get(index), ego, ego.hub))
+	return ego.Ego()
+}
+
+func (ego *ObservableList) Replace(index int, value any) List {
+	old := ego.List.Get(index)
+	ego.List.Replace(index, value)
+	ego.emitAt(index, OpReplace, old, observe(ego.List.Get(index), ego, ego.hub))
+	return ego.Ego()
+}
+
+func (ego *ObservableList) Delete(indexes ...int) List {
+	sorted := make([]int, len(indexes))
+	copy(sorted, indexes)
+	sort.Ints(sorted)
+	ego.hub.run(func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			old := ego.List.Get(sorted[i])
+			ego.List.Delete(sorted[i])
+			ego.emitAt(sorted[i], OpDelete, old, nil)
+		}
+	})
+	return ego.Ego()
+}
+
+func (ego *ObservableList) Clear() List {
+	if ego.List.Empty() {
+		return ego.Ego()
+	}
+	old := NewListFrom(ego.List.Slice())
+	ego.List.Clear()
+	ego.emit("", OpClear, old, nil)
+	return ego.Ego()
+}
+
+/*
+Performs a change reordering the elements and emits a corresponding event.
+
+Parameters:
+  - op - operation to report,
+  - function - function reordering the elements.
+
+Returns:
+  - updated list.
+*/
+func (ego *ObservableList) reorder(op Operation, function func()) List {
+	if ego.List.Count() < 2 {
+		return ego.Ego()
+	}
+	old := NewListFrom(ego.List.Slice())
+	function()
+	ego.emit("", op, old, NewListFrom(ego.List.Slice()))
+	return ego.Ego()
+}
+
+/*
+Sets the order of the elements to a given one and emits a single event of the given operation.
+The elements are replaced by the given ones, which have to be a permutation of the current elements.
+
+Parameters:
+  - op - operation to report,
+  - order - list with the elements in the new order.
+*/
+func (ego *ObservableList) restoreOrder(op Operation, order List) {
+	ego.reorder(op, func() {
+		ego.List.Clear()
+		ego.List.Add(order.Slice()...)
+		ego.observeChildren()
+	})
+}
+
+func (ego *ObservableList) Sort() List {
+	return ego.reorder(OpSort, func() { ego.List.Sort() })
+}
+
+func (ego *ObservableList) SortFunc(less func(any, any) bool) List {
+	return ego.reorder(OpSort, func() { ego.List.SortFunc(less) })
+}
+
+func (ego *ObservableList) SortStable(less func(any, any) bool) List {
+	return ego.reorder(OpSort, func() { ego.List.SortStable(less) })
+}
+
+func (ego *ObservableList) SortBy(tfs ...string) List {
+	return ego.reorder(OpSort, func() { ego.List.SortBy(tfs...) })
+}
+
+func (ego *ObservableList) Reverse() List {
+	return ego.reorder(OpReverse, func() { ego.List.Reverse() })
+}
+
+func (ego *ObservableList) Shuffle(source *rand.Rand) List {
+	return ego.reorder(OpMove, func() { ego.List.Shuffle(source) })
+}
+
+func (ego *ObservableList) Swap(i int, j int) List {
+	return ego.reorder(OpMove, func() { ego.List.Swap(i, j) })
+}
+
+func (ego *ObservableList) Move(from int, to int) List {
+	return ego.reorder(OpMove, func() { ego.List.Move(from, to) })
+}
+
+func (ego *ObservableList) Rotate(k int) List {
+	return ego.reorder(OpMove, func() { ego.List.Rotate(k) })
+}
+
+func (ego *ObservableList) Fill(value any, start int, end int) List {
+	ego.hub.run(func() { ego.List.Fill(value, start, end) })
+	return ego.Ego()
+}
+
+func (ego *ObservableList) InsertAll(index int, values ...any) List {
+	ego.hub.run(func() { ego.List.InsertAll(index, values...) })
+	return ego.Ego()
+}
+
+func (ego *ObservableList) Splice(start int, deleteCount int, items ...any) (removed List) {
+	ego.hub.run(func() { removed = ego.List.Splice(start, deleteCount, items...) })
+	return
+}
+
+func (ego *ObservableList) SetTF(tf string, value any) List {
+	ego.hub.run(func() {
+		setTF(ego.Ego(), tf, value)
+	})
+	return ego.Ego()
+}
+
+func (ego *ObservableList) UnsetTF(tf string) List {
+	ego.hub.run(func() {
+		unsetTF(ego.Ego(), tf)
+	})
+	return ego.Ego()
+}
@@ -0,0 +1,275 @@
+package anytype_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestObservable(t *testing.T) {
+
+	record := func(events *[]anytype.Event) func([]anytype.Event) {
+		return func(e []anytype.Event) {
+			*events = append(*events, e...)
+		}
+	}
+
+	t.Run("object", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("first", 1))
+		var events []anytype.Event
+		o.Subscribe("", record(&events))
+		o.Set("first", 2, "second", 3).Unset("second", "missing")
+		if len(events) != 3 {
+			t.Fatal("wrong number of events")
+		}
+		if events[0] != (anytype.Event{Path: ".first", Op: anytype.OpReplace, Old: 1, New: 2}) {
+			t.Error("replacing a field is not reported properly")
+		}
+		if events[1] != (anytype.Event{Path: ".second", Op: anytype.OpAdd, Old: nil, New: 3}) {
+			t.Error("adding a field is not reported properly")
+		}
+		if events[2] != (anytype.Event{Path: ".second", Op: anytype.OpDelete, Old: 3, New: nil}) {
+			t.Error("unsetting a field is not reported properly")
+		}
+		o.Clear()
+		if len(events) != 4 || events[3].Op != anytype.OpClear || !events[3].Old.(anytype.Object).Equals(Object("first", 2)) {
+			t.Error("clearing is not reported properly")
+		}
+		if !Object().Equals(o) || !o.Equals(Object()) {
+			t.Error("observable object should be equal to its content")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		l := anytype.NewObservableList(List(3, 1))
+		var events []anytype.Event
+		l.Subscribe("", record(&events))
+		l.Add(2).Insert(0, 0).Insert(4, 4).Replace(0, 5).Delete(0, 1).Pop()
+		ops := []anytype.Operation{
+			anytype.OpAdd,
+			anytype.OpInsert,
+			anytype.OpAdd,
+			anytype.OpReplace,
+			anytype.OpDelete,
+			anytype.OpDelete,
+			anytype.OpDelete,
+		}
+		paths := []string{"#2", "#0", "#4", "#0", "#1", "#0", "#2"}
+		if len(events) != len(ops) {
+			t.Fatal("wrong number of events")
+		}
+		for i := range ops {
+			if events[i].Op != ops[i] || events[i].Path != paths[i] {
+				t.Errorf("event %d is not reported properly", i)
+			}
+		}
+		if !l.Equals(List(1, 2)) {
+			t.Error("observable list does not change properly")
+		}
+		events = nil
+		l.Add(0).Sort().Reverse().Clear()
+		if len(events) != 4 || events[1].Op != anytype.OpSort || events[2].Op != anytype.OpReverse || events[3].Op != anytype.OpClear {
+			t.Error("reordering and clearing are not reported properly")
+		}
+		if !events[1].Old.(anytype.List).Equals(List(1, 2, 0)) {
+			t.Error("sorting does not report the former order")
+		}
+		events = nil
+		l.Add(Object("a", 2), Object("a", 1)).SortBy(".a").SortStable(func(a, b any) bool { return false }).SortFunc(func(a, b any) bool { return false })
+		if len(events) != 5 || events[4].Op != anytype.OpSort || !events[4].New.(anytype.List).Equals(List(Object("a", 1), Object("a", 2))) {
+			t.Error("sorting by comparator or keys is not reported properly")
+		}
+	})
+
+	t.Run("structural", func(t *testing.T) {
+		l := anytype.NewObservableList(List(1, 2, 3))
+		var events []anytype.Event
+		l.Subscribe("", record(&events))
+		l.Swap(0, 2).Move(0, 1).Rotate(1)
+		if len(events) != 3 || events[0].Op != anytype.OpMove || !events[2].Old.(anytype.List).Equals(List(2, 3, 1)) || !events[2].New.(anytype.List).Equals(List(1, 2, 3)) {
+			t.Error("moving elements is not reported properly")
+		}
+		events = nil
+		removed := l.Splice(0, 1, "a", "b")
+		if !removed.Equals(List(1)) || len(events) != 3 || events[0].Op != anytype.OpDelete || events[2] != (anytype.Event{Path: "#1", Op: anytype.OpInsert, Old: nil, New: "b"}) {
+			t.Error("splicing is not reported properly")
+		}
+	})
+
+	t.Run("removeIf", func(t *testing.T) {
+		l := anytype.NewObservableList(List(1, "a", 2, "b"))
+		var events []anytype.Event
+		l.Subscribe("", record(&events))
+		l.RemoveIf(func(x any) bool {
+			_, ok := x.(string)
+			return ok
+		})
+		if len(events) != 2 || events[0].Path != "#3" || events[1].Path != "#1" || !l.Equals(List(1, 2)) {
+			t.Error("removing by condition is not reported properly")
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object(
+			"object", Object("list", List(Object())),
+		))
+		var events []anytype.Event
+		o.Subscribe("", record(&events))
+		o.GetObject("object").GetList("list").GetObject(0).Set("test", true)
+		o.GetObject("object").GetList("list").Insert(0, 0)
+		o.GetObject("object").GetList("list").GetObject(1).Set("test", false)
+		if len(events) != 3 {
+			t.Fatal("wrong number of events")
+		}
+		if events[0].Path != ".object.list#0.test" || events[2].Path != ".object.list#1.test" {
+			t.Error("nested changes are not reported with proper paths")
+		}
+		o.Set("new", Object())
+		o.GetObject("new").Set("test", 1)
+		if events[len(events)-1].Path != ".new.test" {
+			t.Error("changes of newly added structures are not reported")
+		}
+		detached := o.GetObject("object")
+		o.Unset("object")
+		events = nil
+		detached.Set("test", 0)
+		if len(events) != 0 {
+			t.Error("changes of detached structures should not be reported")
+		}
+	})
+
+	t.Run("tf", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object())
+		var events []anytype.Event
+		o.Subscribe("", record(&events))
+		o.SetTF(".a.b#1", 1)
+		paths := []string{".a", ".a.b", ".a.b#0", ".a.b#1"}
+		if len(events) != len(paths) {
+			t.Fatal("wrong number of events")
+		}
+		for i := range paths {
+			if events[i].Path != paths[i] {
+				t.Errorf("event %d is not reported properly", i)
+			}
+		}
+		if !o.Equals(Object("a", Object("b", List(nil, 1)))) {
+			t.Error("setting by TF does not work properly")
+		}
+		o.UnsetTF(".a.b#0")
+		if events[len(events)-1] != (anytype.Event{Path: ".a.b#0", Op: anytype.OpDelete, Old: nil, New: nil}) {
+			t.Error("unsetting by TF is not reported properly")
+		}
+		l := anytype.NewObservableList(List())
+		l.Subscribe("", record(&events))
+		l.SetTF("#0.a", 1).UnsetTF("#0.a")
+		if events[len(events)-1].Path != "#0.a" || !l.Equals(List(Object())) {
+			t.Error("TF changes of list are not reported properly")
+		}
+	})
+
+	t.Run("snapshots", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("n", Object()))
+		var events []anytype.Event
+		o.Subscribe("", record(&events))
+		o.SetTF(".n.y.z#2", "q")
+		if len(events) != 5 || !events[0].New.(anytype.Object).Empty() || !events[1].New.(anytype.List).Empty() {
+			t.Error("new values of events are not copied")
+		}
+		mirror := Object("n", Object())
+		for _, event := range events {
+			if segments := strings.Split(event.Path, "#"); len(segments) == 2 {
+				mirror.GetTF(segments[0]).(anytype.List).Add(event.New)
+			} else {
+				mirror.SetTF(event.Path, event.New)
+			}
+		}
+		if !mirror.Equals(o) {
+			t.Errorf("replayed events do not reproduce the object: %s", mirror)
+		}
+		events = nil
+		y := o.GetObject("n").GetObject("y")
+		o.GetObject("n").Set("y", 1)
+		y.Set("w", 2)
+		if !events[0].Old.(anytype.Object).Equals(Object("z", List(nil, nil, "q"))) {
+			t.Error("old values of events are not copied")
+		}
+	})
+
+	t.Run("filtering", func(t *testing.T) {
+		o := anytype.NewObservableObject(Object("a", Object(), "ab", Object()))
+		var a, ab, all []anytype.Event
+		o.Subscribe(".a", record(&a))
+		id := o.Subscribe(".ab", record(&ab))
+		o.Subscribe("", record(&all))
+		o.GetObject("a").Set("x", 1)
+		o.GetObject("ab").Set("x", 1)
+		o.Set("a", 0)
+		if len(a) != 2 || len(ab) != 1 || len(all) != 3 {
+			t.Error("prefix filtering does not work properly")
+		}
+		o.Unsubscribe(id)
+		o.Clear()
+		if len(ab) != 1 || len(a) != 3 {
+			t.Error("unsubscribing or reporting of changes of ancestors does not work properly")
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		l := anytype.NewObservableList(List())
+		calls := 0
+		var events []anytype.Event
+		l.Subscribe("", func(e []anytype.Event) {
+			calls++
+			events = append(events, e...)
+		})
+		l.Batch(func() {
+			l.Add(1, 2)
+			l.Batch(func() {
+				l.Add(3)
+			})
+			if calls != 0 {
+				t.Error("events should not be delivered during a batch")
+			}
+		})
+		if calls != 1 || len(events) != 3 {
+			t.Error("batched delivery does not work properly")
+		}
+	})
+
+}
+
+func TestObservablePanics(t *testing.T) {
+
+	catch := func(msg string) {
+		if r := recover(); r == nil {
+			t.Error(msg)
+		}
+	}
+
+	t.Run("invalidSet", func(t *testing.T) {
+		defer catch("setting odd number of values did not cause panic")
+		anytype.NewObservableObject(Object()).Set("test")
+	})
+
+	t.Run("invalidKey", func(t *testing.T) {
+		defer catch("setting non-string key did not cause panic")
+		anytype.NewObservableObject(Object()).Set(0, 0)
+	})
+
+	t.Run("invalidSetTF", func(t *testing.T) {
+		defer catch("setting invalid tree form did not cause panic")
+		anytype.NewObservableObject(Object()).SetTF("#0", 0)
+	})
+
+	t.Run("invalidUnsetTF", func(t *testing.T) {
+		defer catch("unsetting invalid tree form did not cause panic")
+		anytype.NewObservableList(List()).UnsetTF(".test")
+	})
+
+	t.Run("invalidReplace", func(t *testing.T) {
+		defer catch("replacing non-existing item did not cause panic")
+		anytype.NewObservableList(List()).Replace(0, 0)
+	})
+
+}
@@ -65,14 +65,15 @@ func parseField(field string, line int) (any, error) {
 Recursively parses a JSON list.
 Patameters:
   - json - JSON string to parse,
-  - line - current line of the input.
+  - line - current line of the input,
+  - newObject - constructor of the nested objects.
 
 Returns:
   - created list,
   - number of bytes processed,
   - error if any occurred.
 */
-func parseList(json string, line *int) (List, int, error) {
+func parseList(json string, line *int, newObject func(values ...any) Object) (List, int, error) {
 
 	state := stateStart
 	var list List
@@ -120,7 +121,7 @@ func parseList(json string, line *int) (List, int, error) {
 			// Recursive call with original string starting from current position
 			// Current index is moved after the nested object so the parsing can continue
 			if !inVal && char == '{' {
-				o, pos, err := parseObject(json[i:], line)
+				o, pos, err := parseObject(json[i:], line, newObject)
 				if err != nil {
 					return nil, 0, err
 				}
@@ -131,7 +132,7 @@ func parseList(json string, line *int) (List, int, error) {
 
 			// Nested list (same as above)
 			if !inVal && char == '[' {
-				l, pos, err := parseList(json[i:], line)
+				l, pos, err := parseList(json[i:], line, newObject)
 				if err != nil {
 					return nil, 0, err
 				}
@@ -195,7 +196,7 @@ func parseList(json string, line *int) (List, int, error) {
 	}
 
 	// No matching rule - error
-	return nil, 0, fmt.Errorf("not a valid JSON - unexpected end of input")
+	return nil, 0, fmt.Errorf("not a valid JSON - unexpected end of input on line %d", *line)
 
 }
 
@@ -203,14 +204,15 @@ func parseList(json string, line *int) (List, int, error) {
 Recursively parses a JSON object.
 Patameters:
   - json - JSON string to parse,
-  - line - current line of the input.
+  - line - current line of the input,
+  - newObject - constructor of the object and the nested objects.
 
 Returns:
   - created object,
   - number of bytes processed,
   - error if any occurred.
 */
-func parseObject(json string, line *int) (Object, int, error) {
+func parseObject(json string, line *int, newObject func(values ...any) Object) (Object, int, error) {
 
 	state := stateStart
 	var object Object
@@ -237,7 +239,7 @@ func parseObject(json string, line *int) (Object, int, error) {
 
 		// Object creation
 		case stateStart:
-			object = NewObject()
+			object = newObject()
 			state = stateKeyStart
 
 		// Begining of a key
@@ -304,7 +306,7 @@ func parseObject(json string, line *int) (Object, int, error) {
 			// Recursive call with original string starting from current position
 			// Current index is moved after the nested object so the parsing can continue
 			if !inVal && char == '{' {
-				o, pos, err := parseObject(json[i:], line)
+				o, pos, err := parseObject(json[i:], line, newObject)
 				if err != nil {
 					return nil, 0, err
 				}
@@ -316,7 +318,7 @@ func parseObject(json string, line *int) (Object, int, error) {
 
 			// Nested list (same as above)
 			if !inVal && char == '[' {
-				l, pos, err := parseList(json[i:], line)
+				l, pos, err := parseList(json[i:], line, newObject)
 				if err != nil {
 					return nil, 0, err
 				}
@@ -404,7 +406,7 @@ func parseObject(json string, line *int) (Object, int, error) {
 	}
 
 	// No matching rule - error
-	return nil, 0, fmt.Errorf("not a valid JSON - unexpected end of input")
+	return nil, 0, fmt.Errorf("not a valid JSON - unexpected end of input on line %d", *line)
 
 }
 
@@ -418,12 +420,26 @@ Returns:
   - error if any occurred.
 */
 func ParseList(json string) (List, error) {
+	return parseRootList(json, NewObject)
+}
+
+/*
+Parses a JSON list, creating the objects by a given constructor.
+Patameters:
+  - json - JSON string to parse,
+  - newObject - constructor of the objects.
+
+Returns:
+  - created list,
+  - error if any occurred.
+*/
+func parseRootList(json string, newObject func(values ...any) Object) (List, error) {
 	start := strings.Index(json, "[")
 	if start < 0 {
 		return nil, fmt.Errorf("not a valid JSON - missing '['")
 	}
 	startLine := strings.Count(json[:start], "\n") + 1
-	root, _, err := parseList(json[start:], &startLine)
+	root, _, err := parseList(json[start:], &startLine, newObject)
 	return root, err
 }
 
@@ -437,15 +453,109 @@ Returns:
   - error if any occurred.
 */
 func ParseObject(json string) (Object, error) {
+	return parseRootObject(json, NewObject)
+}
+
+/*
+Parses a JSON object, creating the objects by a given constructor.
+Patameters:
+  - json - JSON string to parse,
+  - newObject - constructor of the objects.
+
+Returns:
+  - created object,
+  - error if any occurred.
+*/
+func parseRootObject(json string, newObject func(values ...any) Object) (Object, error) {
 	start := strings.Index(json, "{")
 	if start < 0 {
 		return nil, fmt.Errorf("not a valid JSON - missing '{'")
 	}
 	startLine := strings.Count(json[:start], "\n") + 1
-	root, _, err := parseObject(json[start:], &startLine)
+	root, _, err := parseObject(json[start:], &startLine, newObject)
 	return root, err
 }
 
+/*
+Options of the JSON parser.
+*/
+type ParserOptions struct {
+	// Key normalizer; if set, all parsed objects are created as objects with normalized keys (see NewNormalizedObject).
+	// Of colliding keys, the first spelling in the document is kept and the last value wins.
+	KeyNormalizer func(key string) string
+	// Relaxed syntax; if set, the input is parsed as JSON5 (see ParseJSON5), allowing comments, trailing commas etc.
+	Relaxed bool
+}
+
+/*
+Creates a constructor of the parsed objects.
+With a key normalizer, the keys are normalized during the parsing in the document order,
+so the first spelling of colliding keys is kept and the last value wins.
+
+Returns:
+  - the constructor.
+*/
+func (ego ParserOptions) objectConstructor() func(values ...any) Object {
+	if ego.KeyNormalizer == nil {
+		return NewObject
+	}
+	return func(values ...any) Object {
+		return NewNormalizedObject(ego.KeyNormalizer, values...)
+	}
+}
+
+/*
+ParseListWith creates a new list from JSON, using the given parser options.
+Patameters:
+  - json - JSON string to parse,
+  - options - parser options.
+
+Returns:
+  - created list,
+  - error if any occurred.
+*/
+func ParseListWith(json string, options ParserOptions) (List, error) {
+	newObject := options.objectConstructor()
+	if !options.Relaxed {
+		return parseRootList(json, newObject)
+	}
+	_, value, err := parseJSON5(json, newObject)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := value.(List)
+	if !ok {
+		return nil, fmt.Errorf("not a valid JSON5 - the root is not a list")
+	}
+	return root, nil
+}
+
+/*
+ParseObjectWith creates a new object from JSON, using the given parser options.
+Patameters:
+  - json - JSON string to parse,
+  - options - parser options.
+
+Returns:
+  - created object,
+  - error if any occurred.
+*/
+func ParseObjectWith(json string, options ParserOptions) (Object, error) {
+	newObject := options.objectConstructor()
+	if !options.Relaxed {
+		return parseRootObject(json, newObject)
+	}
+	_, value, err := parseJSON5(json, newObject)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := value.(Object)
+	if !ok {
+		return nil, fmt.Errorf("not a valid JSON5 - the root is not an object")
+	}
+	return root, nil
+}
+
 /*
 ParseFile creates a new object from JSON file.
 Patameters:
@@ -0,0 +1,311 @@
+/*
+AnyType Library for Go
+Java properties parser and writer
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+/*
+Acquires a nested object specified by dotted path segments, missing objects are created.
+
+Parameters:
+  - object - object to start from,
+  - segments - keys of the nested objects.
+
+Returns:
+  - the nested object,
+  - error if any of the segments is empty or holds a value which is not an object.
+*/
+func dottedObject(object Object, segments []string) (Object, error) {
+	for i, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("empty segment in '%s'", strings.Join(segments, "."))
+		}
+		switch object.TypeOf(segment) {
+		case TypeUndefined:
+			nested := NewObject()
+			object.Set(segment, nested)
+			object = nested
+		case TypeObject:
+			object = object.GetObject(segment)
+		default:
+			return nil, fmt.Errorf("'%s' conflicts with a value", strings.Join(segments[:i+1], "."))
+		}
+	}
+	return object, nil
+}
+
+/*
+Sets a value specified by a dotted key, missing objects on the path are created.
+
+Parameters:
+  - object - object to start from,
+  - key - dotted key,
+  - value - value to set.
+
+Returns:
+  - error if the key is not valid or conflicts with an existing value.
+*/
+func setDottedKey(object Object, key string, value any) error {
+	segments := strings.Split(key, ".")
+	last := segments[len(segments)-1]
+	if len(segments) > 1 && last == "" {
+		return fmt.Errorf("empty segment in '%s'", key)
+	}
+	parent, err := dottedObject(object, segments[:len(segments)-1])
+	if err != nil {
+		return err
+	}
+	if parent.TypeOf(last) == TypeObject {
+		return fmt.Errorf("'%s' conflicts with an object", key)
+	}
+	parent.Set(last, value)
+	return nil
+}
+
+/*
+Finds the end of a properties key, which is terminated by an unescaped '=', ':' or whitespace.
+
+Parameters:
+  - line - logical line.
+
+Returns:
+  - index of the end of the key.
+*/
+func propertiesKeyEnd(line string) int {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':', ' ', '\t', '\f':
+			return i
+		}
+	}
+	return len(line)
+}
+
+/*
+Replaces escape sequences of properties.
+
+Parameters:
+  - str - escaped string.
+
+Returns:
+  - unescaped string,
+  - error if a unicode escape sequence is malformed.
+*/
+func unescapeProperties(str string) (string, error) {
+	if !strings.Contains(str, "\\") {
+		return str, nil
+	}
+	var result strings.Builder
+	var high rune
+	for i := 0; i < len(str); i++ {
+		char := str[i]
+		if char != '\\' || i == len(str)-1 {
+			result.WriteByte(char)
+			continue
+		}
+		i++
+		switch str[i] {
+		case 't':
+			result.WriteByte('\t')
+		case 'n':
+			result.WriteByte('\n')
+		case 'r':
+			result.WriteByte('\r')
+		case 'f':
+			result.WriteByte('\f')
+		case 'u':
+			if i+5 > len(str) {
+				return "", fmt.Errorf("malformed \\uxxxx encoding")
+			}
+			code, err := strconv.ParseUint(str[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uxxxx encoding")
+			}
+			i += 4
+			char := rune(code)
+			switch {
+			case utf16.IsSurrogate(char) && char < 0xdc00:
+				high = char
+				continue
+			case high != 0:
+				char = utf16.DecodeRune(high, char)
+			}
+			result.WriteRune(char)
+		default:
+			result.WriteByte(str[i])
+		}
+		high = 0
+	}
+	return result.String(), nil
+}
+
+/*
+Checks whether a line ends with an escaping backslash (an odd number of backslashes).
+
+Parameters:
+  - line - line to check.
+
+Returns:
+  - true if the line continues on the next line, false otherwise.
+*/
+func continuesLine(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+/*
+ParseProperties creates a new object from a Java properties file.
+Dotted keys are mapped to nested objects (e.g. "db.host" to {"db": {"host": ...}}), all values are strings.
+Comments ('#' or '!'), line continuations and escape sequences (including unicode ones) are supported.
+
+Parameters:
+  - properties - properties string to parse.
+
+Returns:
+  - created object,
+  - error if any occurred (with the line).
+*/
+func ParseProperties(properties string) (Object, error) {
+	result := NewObject()
+	lines := strings.Split(strings.TrimPrefix(properties, "\ufeff"), "\n")
+	for i := 0; i < len(lines); i++ {
+		number := i + 1
+		line := strings.TrimLeft(strings.TrimSuffix(lines[i], "\r"), " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		for continuesLine(line) {
+			line = line[:len(line)-1]
+			if i++; i == len(lines) {
+				break
+			}
+			line += strings.TrimLeft(strings.TrimSuffix(lines[i], "\r"), " \t\f")
+		}
+		end := propertiesKeyEnd(line)
+		rest := strings.TrimLeft(line[end:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+		key, err := unescapeProperties(line[:end])
+		if err == nil {
+			var value string
+			if value, err = unescapeProperties(rest); err == nil {
+				err = setDottedKey(result, key, value)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a valid properties file - %s on line %d", err, number)
+		}
+	}
+	return result, nil
+}
+
+/*
+Escapes a key or a value of properties.
+
+Parameters:
+  - str - string to escape,
+  - key - true for a key (all spaces are escaped), false for a value (only a leading space is escaped).
+
+Returns:
+  - escaped string.
+*/
+func escapeProperties(str string, key bool) string {
+	var result strings.Builder
+	for i, char := range str {
+		switch char {
+		case ' ':
+			if key || i == 0 {
+				result.WriteByte('\\')
+			}
+			result.WriteByte(' ')
+		case '\\', '=', ':', '#', '!':
+			result.WriteByte('\\')
+			result.WriteRune(char)
+		case '\t':
+			result.WriteString(`\t`)
+		case '\n':
+			result.WriteString(`\n`)
+		case '\r':
+			result.WriteString(`\r`)
+		case '\f':
+			result.WriteString(`\f`)
+		default:
+			if char >= 0x20 && char <= 0x7e {
+				result.WriteRune(char)
+				continue
+			}
+			units := []uint16{uint16(char)}
+			if char > 0xffff {
+				units = utf16.Encode([]rune{char})
+			}
+			for _, unit := range units {
+				fmt.Fprintf(&result, `\u%04X`, unit)
+			}
+		}
+	}
+	return result.String()
+}
+
+/*
+Converts a scalar value to a string of a flat format (properties or INI).
+
+Parameters:
+  - value - value to convert.
+
+Returns:
+  - the string (empty for nil).
+*/
+func formatFlatValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		if math.IsInf(typed, 0) || math.IsNaN(typed) {
+			return strings.TrimPrefix(strconv.FormatFloat(typed, 'g', -1, 64), "+")
+		}
+		return strconv.FormatFloat(typed, 'g', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+/*
+ToProperties exports an object to a Java properties file.
+Nested objects and lists are flattened into dotted keys (list indexes become numeric segments), keys are sorted alphabetically.
+Nil is written as an empty value, empty objects and lists are omitted. Characters out of ASCII are written as unicode escape sequences.
+
+Parameters:
+  - object - object to export.
+
+Returns:
+  - properties string.
+*/
+func ToProperties(object Object) string {
+	flat := object.FlattenWith(FlattenOptions{Separator: "."})
+	var result strings.Builder
+	for _, key := range sortedKeysOf(flat) {
+		switch value := flat.Get(key).(type) {
+		case Object, List:
+			continue
+		default:
+			result.WriteString(escapeProperties(key, true) + "=" + escapeProperties(formatFlatValue(value), false) + "\n")
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,83 @@
+package anytype_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestProperties(t *testing.T) {
+
+	t.Run("parse", func(t *testing.T) {
+		o, err := anytype.ParseProperties("# comment\n! comment\n\ndb.host = localhost\ndb.port:5432\r\nname value\n" +
+			"path = C:\\\\temp\\\n    \\\\files\nkey\\ with\\=chars = \\ padded\ntext = caf\\u00e9 \\uD83D\\uDE00\\tend\nempty\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !o.Equals(Object(
+			"db", Object("host", "localhost", "port", "5432"),
+			"name", "value",
+			"path", "C:\\temp\\files",
+			"key with=chars", " padded",
+			"text", "café \U0001F600\tend",
+			"empty", "",
+		)) {
+			t.Errorf("parsing of properties does not work properly: %s", o)
+		}
+		o, err = anytype.ParseProperties("\ufeffa=1\na=2\nb.c=3\nb.d=4")
+		if err != nil || !o.Equals(Object("a", "2", "b", Object("c", "3", "d", "4"))) {
+			t.Error("parsing of repeated keys does not work properly")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object(
+			"db", Object("host", "localhost", "port", 5432, "ssl", true),
+			"list", List("a", 3.5, nil),
+			"empty", Object(),
+			"text", " a=b:c #\n",
+			"key with space", "café",
+		)
+		expected := "db.host=localhost\ndb.port=5432\ndb.ssl=true\nkey\\ with\\ space=caf\\u00E9\n" +
+			"list.0=a\nlist.1=3.5\nlist.2=\ntext=\\ a\\=b\\:c \\#\\n\n"
+		if str := anytype.ToProperties(o); str != expected {
+			t.Errorf("object is not exported properly:\n%s", str)
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o := Object(
+			"a", Object("b", Object("c", "deep"), "d", "\\ back slash "),
+			"emoji", "\U0001F600",
+			"multi", "line\r\nbreak",
+			"!", "#",
+		)
+		parsed, err := anytype.ParseProperties(anytype.ToProperties(o))
+		if err != nil || !parsed.Equals(o) {
+			t.Error("exported properties do not round-trip")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for properties, message := range map[string]string{
+			"a=\\u12":           "on line 1",
+			"a=1\nb=\\uXYZW":    "on line 2",
+			"a=1\na.b=2":        "on line 2",
+			"a.b=1\na=2":        "on line 2",
+			"a..b=1":            "on line 1",
+			"# c\n\nx=1\na.=1":  "on line 4",
+			"a=\\\n1\nb.=\\\n2": "on line 3",
+		} {
+			_, err := anytype.ParseProperties(properties)
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", properties)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), message) {
+				t.Errorf("wrong error reported for %q: %s", properties, err)
+			}
+		}
+	})
+
+}
@@ -0,0 +1,393 @@
+/*
+AnyType Library for Go
+URL query string and form encoding
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+QueryNotation is a convention for encoding nested objects and lists in query strings.
+*/
+type QueryNotation uint8
+
+const (
+	// Nested keys in brackets, list indexes as keys (a[b][0]=x), empty brackets append to a list when parsing (a[]=x).
+	QueryBrackets QueryNotation = iota
+	// Nested keys separated by dots, list indexes as keys (a.b.0=x).
+	QueryDotted
+	// Nested keys separated by dots, lists as repeated keys (a.b=x&a.b=y).
+	QueryRepeated
+)
+
+/*
+QueryOptions configures the conversion between query strings and objects.
+
+Fields:
+  - Notation - convention for nested objects and lists, brackets by default,
+  - InferTypes - integers, floats and booleans are parsed to typed values instead of strings (ParseQuery, ParseValues).
+*/
+type QueryOptions struct {
+	Notation   QueryNotation
+	InferTypes bool
+}
+
+/*
+Splits a query key into the segments of the path.
+
+Parameters:
+  - key - the key,
+  - notation - notation of the key.
+
+Returns:
+  - segments of the path (an empty segment means appending to a list),
+  - error if the key is malformed.
+*/
+func querySegments(key string, notation QueryNotation) ([]string, error) {
+	if notation != QueryBrackets {
+		segments := strings.Split(key, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("empty segment in key '%s'", key)
+			}
+		}
+		return segments, nil
+	}
+	open := strings.IndexByte(key, '[')
+	if open < 0 {
+		open = len(key)
+	}
+	if open == 0 {
+		return nil, fmt.Errorf("malformed key '%s'", key)
+	}
+	segments := []string{key[:open]}
+	for rest := key[open:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 || strings.IndexByte(rest[1:end], '[') >= 0 {
+			return nil, fmt.Errorf("malformed key '%s'", key)
+		}
+		segments = append(segments, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return segments, nil
+}
+
+/*
+Converts a query value to a field.
+
+Parameters:
+  - value - the value,
+  - infer - whether the type should be inferred.
+
+Returns:
+  - the field.
+*/
+func parseQueryValue(value string, infer bool) any {
+	if !infer {
+		return value
+	}
+	switch {
+	case value == "true":
+		return true
+	case value == "false":
+		return false
+	case csvInt.MatchString(value):
+		if number, err := strconv.ParseInt(value, 10, strconv.IntSize); err == nil {
+			return int(number)
+		}
+	case csvFloat.MatchString(value):
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
+
+/*
+Stores a single query parameter to an object.
+Nested structures are created as objects, lists are made from them afterwards (see queryLists).
+Repeated values of a key are collected into a list.
+
+Parameters:
+  - object - object to store to,
+  - key - key of the parameter,
+  - value - value of the parameter,
+  - options - query options.
+
+Returns:
+  - error if the key is malformed or conflicts with another key.
+*/
+func setQueryParam(object Object, key string, value string, options QueryOptions) error {
+	segments, err := querySegments(key, options.Notation)
+	if err != nil {
+		return err
+	}
+	last := len(segments) - 1
+	for i, segment := range segments {
+		if segment == "" {
+			segment = strconv.Itoa(object.Count())
+		}
+		if i == last {
+			field := parseQueryValue(value, options.InferTypes)
+			switch object.TypeOf(segment) {
+			case TypeUndefined:
+				object.Set(segment, field)
+			case TypeObject:
+				return fmt.Errorf("key '%s' conflicts with a nested key", key)
+			case TypeList:
+				object.GetList(segment).Add(field)
+			default:
+				object.Set(segment, NewList(object.Get(segment), field))
+			}
+			break
+		}
+		switch object.TypeOf(segment) {
+		case TypeUndefined:
+			nested := NewObject()
+			object.Set(segment, nested)
+			object = nested
+		case TypeObject:
+			object = object.GetObject(segment)
+		default:
+			return fmt.Errorf("key '%s' conflicts with a value", key)
+		}
+	}
+	return nil
+}
+
+/*
+Converts nested objects with keys 0 to n-1 into lists (recursively).
+
+Parameters:
+  - object - object to convert.
+
+Returns:
+  - the list if the object has been converted, the object otherwise.
+*/
+func queryLists(object Object) any {
+	for _, key := range object.Keys().StringSlice() {
+		if nested, ok := object.Get(key).(Object); ok {
+			object.Set(key, queryLists(nested))
+		}
+	}
+	count := object.Count()
+	if count == 0 {
+		return object
+	}
+	values := make([]any, count)
+	for _, key := range object.Keys().StringSlice() {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= count || strconv.Itoa(index) != key {
+			return object
+		}
+		values[index] = object.Get(key)
+	}
+	return NewList(values...)
+}
+
+/*
+Creates the resulting object from the parsed parameters.
+
+Parameters:
+  - object - object with the parameters.
+
+Returns:
+  - the object with lists in place of the objects with indexes.
+*/
+func queryResult(object Object) Object {
+	for _, key := range object.Keys().StringSlice() {
+		if nested, ok := object.Get(key).(Object); ok {
+			object.Set(key, queryLists(nested))
+		}
+	}
+	return object
+}
+
+/*
+ParseQuery creates a new object from a URL query string (or a form-encoded body), a leading '?' is ignored.
+Nested objects and lists are decoded according to the notation in the options, repeated keys are collected into lists.
+Nested objects with keys 0 to n-1 become lists.
+
+Parameters:
+  - query - query string to parse,
+  - options - query options.
+
+Returns:
+  - created object,
+  - error if any occurred.
+*/
+func ParseQuery(query string, options QueryOptions) (Object, error) {
+	result := NewObject()
+	for _, param := range strings.Split(strings.TrimPrefix(query, "?"), "&") {
+		if param == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(param, "=")
+		key, err := url.QueryUnescape(key)
+		if err == nil {
+			value, err = url.QueryUnescape(value)
+		}
+		if err == nil {
+			err = setQueryParam(result, key, value, options)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a valid query - %s", err)
+		}
+	}
+	return queryResult(result), nil
+}
+
+/*
+ParseValues creates a new object from URL values (e.g. a parsed form of a request), the same way as ParseQuery.
+Keys are processed in alphabetical order.
+
+Parameters:
+  - values - values to convert,
+  - options - query options.
+
+Returns:
+  - created object,
+  - error if any occurred.
+*/
+func ParseValues(values url.Values, options QueryOptions) (Object, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := NewObject()
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if err := setQueryParam(result, key, value, options); err != nil {
+				return nil, fmt.Errorf("not a valid query - %s", err)
+			}
+		}
+	}
+	return queryResult(result), nil
+}
+
+/*
+Creates a query key of a nested field.
+
+Parameters:
+  - key - query key of the parent (empty for the top level),
+  - segment - key or index of the field within the parent,
+  - notation - notation of the key.
+
+Returns:
+  - the query key,
+  - error if the segment cannot be written in the notation.
+*/
+func queryKey(key string, segment string, notation QueryNotation) (string, error) {
+	if segment == "" || notation == QueryBrackets && strings.ContainsAny(segment, "[]") ||
+		notation != QueryBrackets && strings.Contains(segment, ".") {
+		return "", fmt.Errorf("key '%s' cannot be written in query", segment)
+	}
+	switch {
+	case key == "":
+		return segment, nil
+	case notation == QueryBrackets:
+		return key + "[" + segment + "]", nil
+	}
+	return key + "." + segment, nil
+}
+
+/*
+Stores a field (and its nested fields) to URL values.
+
+Parameters:
+  - values - values to store to,
+  - key - query key of the field,
+  - field - the field,
+  - options - query options.
+
+Returns:
+  - error if the field cannot be encoded.
+*/
+func addQueryField(values url.Values, key string, field any, options QueryOptions) error {
+	var keys []string
+	var items []any
+	switch typed := field.(type) {
+	case Object:
+		keys = sortedKeysOf(typed)
+		for _, segment := range keys {
+			items = append(items, typed.Get(segment))
+		}
+	case List:
+		if options.Notation == QueryRepeated {
+			for i := 0; i < typed.Count(); i++ {
+				switch item := typed.Get(i).(type) {
+				case Object, List:
+					return fmt.Errorf("'%s' contains a nested structure which cannot be written as repeated keys", key)
+				default:
+					values.Add(key, formatFlatValue(item))
+				}
+			}
+			return nil
+		}
+		for i := 0; i < typed.Count(); i++ {
+			keys = append(keys, strconv.Itoa(i))
+			items = append(items, typed.Get(i))
+		}
+	default:
+		values.Add(key, formatFlatValue(field))
+		return nil
+	}
+	for i, segment := range keys {
+		nested, err := queryKey(key, segment, options.Notation)
+		if err != nil {
+			return err
+		}
+		if err := addQueryField(values, nested, items[i], options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ToValues converts an object to URL values.
+Nested objects and lists are encoded according to the notation in the options, empty objects and lists are omitted and nil becomes an empty value.
+
+Parameters:
+  - object - object to convert,
+  - options - query options.
+
+Returns:
+  - created URL values,
+  - error if a key cannot be written in the notation or a list cannot be written as repeated keys.
+*/
+func ToValues(object Object, options QueryOptions) (url.Values, error) {
+	values := make(url.Values)
+	if err := addQueryField(values, "", object, options); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+/*
+ToQuery exports an object to a URL query string (without a leading '?'), the reverse of ParseQuery.
+Keys are sorted alphabetically and escaped (including the brackets).
+
+Parameters:
+  - object - object to export,
+  - options - query options.
+
+Returns:
+  - query string,
+  - error if the object cannot be written (see ToValues).
+*/
+func ToQuery(object Object, options QueryOptions) (string, error) {
+	values, err := ToValues(object, options)
+	if err != nil {
+		return "", err
+	}
+	return values.Encode(), nil
+}
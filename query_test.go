@@ -0,0 +1,140 @@
+package anytype_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestQuery(t *testing.T) {
+
+	t.Run("parse", func(t *testing.T) {
+		o, err := anytype.ParseQuery("?q=go+lang&filter[status]=open&filter[tags][]=a&filter[tags][]=b&sort[1]=name&sort[0]=id&page=2&page=3&empty=&flag", anytype.QueryOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !o.Equals(Object(
+			"q", "go lang",
+			"filter", Object("status", "open", "tags", List("a", "b")),
+			"sort", List("id", "name"),
+			"page", List("2", "3"),
+			"empty", "",
+			"flag", "",
+		)) {
+			t.Errorf("parsing of bracket notation does not work properly: %s", o)
+		}
+		o, err = anytype.ParseQuery("a.b.0.c=x&a.b.1.c=y&a.d=%C3%A9&list.2=z", anytype.QueryOptions{Notation: anytype.QueryDotted})
+		if err != nil || !o.Equals(Object(
+			"a", Object("b", List(Object("c", "x"), Object("c", "y")), "d", "é"),
+			"list", Object("2", "z"),
+		)) {
+			t.Errorf("parsing of dotted notation does not work properly: %s", o)
+		}
+		o, err = anytype.ParseQuery("id=1&id=2&user.name=x&user.age=30", anytype.QueryOptions{Notation: anytype.QueryRepeated, InferTypes: true})
+		if err != nil || !o.Equals(Object("id", List(1, 2), "user", Object("name", "x", "age", 30))) {
+			t.Errorf("parsing of repeated notation does not work properly: %s", o)
+		}
+	})
+
+	t.Run("inferTypes", func(t *testing.T) {
+		o, err := anytype.ParseQuery("i=-42&f=3.5&e=1e3&t=true&n=false&z=007&s=abc&b=True&x=", anytype.QueryOptions{InferTypes: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !o.Equals(Object("i", -42, "f", 3.5, "e", 1000.0, "t", true, "n", false, "z", "007", "s", "abc", "b", "True", "x", "")) {
+			t.Errorf("type inference does not work properly: %s", o)
+		}
+	})
+
+	t.Run("values", func(t *testing.T) {
+		values := url.Values{"a[b]": {"1"}, "a[c][]": {"x", "y"}, "d": {"z"}}
+		o, err := anytype.ParseValues(values, anytype.QueryOptions{})
+		if err != nil || !o.Equals(Object("a", Object("b", "1", "c", List("x", "y")), "d", "z")) {
+			t.Errorf("parsing of URL values does not work properly: %s", o)
+		}
+		values, err = anytype.ToValues(Object("a", Object("b", 1, "c", List("x", "y")), "d", nil), anytype.QueryOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values.Get("a[b]") != "1" || values.Get("a[c][1]") != "y" || !values.Has("d") || len(values) != 4 {
+			t.Errorf("conversion to URL values does not work properly: %v", values)
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object("q", "a b&c", "filter", Object("tags", List("x", 1.5)), "page", 2, "none", List())
+		for notation, expected := range map[anytype.QueryNotation]string{
+			anytype.QueryBrackets: "filter%5Btags%5D%5B0%5D=x&filter%5Btags%5D%5B1%5D=1.5&page=2&q=a+b%26c",
+			anytype.QueryDotted:   "filter.tags.0=x&filter.tags.1=1.5&page=2&q=a+b%26c",
+			anytype.QueryRepeated: "filter.tags=x&filter.tags=1.5&page=2&q=a+b%26c",
+		} {
+			str, err := anytype.ToQuery(o, anytype.QueryOptions{Notation: notation})
+			if err != nil || str != expected {
+				t.Errorf("object is not exported properly: %s", str)
+			}
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o := Object(
+			"user", Object("name", "Jan Novák", "roles", List("admin", "dev"), "address", Object("city", "Praha")),
+			"items", List(Object("id", 1, "qty", 2), Object("id", 3, "qty", 4)),
+			"active", true,
+			"score", 9.5,
+		)
+		for _, notation := range []anytype.QueryNotation{anytype.QueryBrackets, anytype.QueryDotted} {
+			options := anytype.QueryOptions{Notation: notation, InferTypes: true}
+			str, err := anytype.ToQuery(o, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := anytype.ParseQuery(str, options)
+			if err != nil || !parsed.Equals(o) {
+				t.Errorf("exported query does not round-trip: %s", str)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for query, notation := range map[string]anytype.QueryNotation{
+			"a=%zz":            anytype.QueryBrackets,
+			"%=1":              anytype.QueryBrackets,
+			"[a]=1":            anytype.QueryBrackets,
+			"a[b=1":            anytype.QueryBrackets,
+			"a[b]c=1":          anytype.QueryBrackets,
+			"a[[b]]=1":         anytype.QueryBrackets,
+			"a=1&a[b]=2":       anytype.QueryBrackets,
+			"a[b]=1&a=2":       anytype.QueryBrackets,
+			"a..b=1":           anytype.QueryDotted,
+			".a=1":             anytype.QueryRepeated,
+			"a.b=1&a.b.c=2":    anytype.QueryRepeated,
+			"x=1&a.b.c=1&a.b=": anytype.QueryDotted,
+		} {
+			_, err := anytype.ParseQuery(query, anytype.QueryOptions{Notation: notation})
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", query)
+				continue
+			}
+			if !strings.HasPrefix(err.Error(), "not a valid query - ") {
+				t.Errorf("wrong error reported for %q: %s", query, err)
+			}
+		}
+		for o, notation := range map[string]anytype.QueryNotation{
+			`{"":1}`:          anytype.QueryBrackets,
+			`{"a[b]":1}`:      anytype.QueryBrackets,
+			`{"a":{"b]":1}}`:  anytype.QueryBrackets,
+			`{"a.b":1}`:       anytype.QueryDotted,
+			`{"a":{"b.c":1}}`: anytype.QueryRepeated,
+			`{"a":[[1]]}`:     anytype.QueryRepeated,
+			`{"a":[{"b":1}]}`: anytype.QueryRepeated,
+		} {
+			object, _ := anytype.ParseObject(o)
+			if _, err := anytype.ToQuery(object, anytype.QueryOptions{Notation: notation}); err == nil {
+				t.Errorf("exporting of %s does not return expected error", o)
+			}
+		}
+	})
+
+}
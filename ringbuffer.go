@@ -0,0 +1,101 @@
+/*
+AnyType Library for Go
+Ring buffer implementation of the List
+*/
+
+package anytype
+
+/*
+Ring buffer, a list with a bounded number of elements.
+When the capacity is exceeded, the oldest (first) elements are evicted.
+Elements inserted at the beginning are kept, the last elements are evicted instead.
+
+Implements:
+  - field,
+  - List.
+*/
+type ringBuffer struct {
+	*list
+	capacity int
+}
+
+/*
+NewRingBuffer creates a new ring buffer.
+Adding elements over the capacity evicts the first elements of the list.
+Inserting at the beginning of a full ring buffer (Insert at index 0, Unshift) evicts the last elements instead,
+so the inserted elements are kept.
+Causes a panic if the capacity is lower than 1.
+
+Parameters:
+  - capacity - maximal number of elements,
+  - values... - any amount of initial elements.
+
+Returns:
+  - pointer to the created ring buffer.
+*/
+func NewRingBuffer(capacity int, values ...any) List {
+	if capacity < 1 {
+		panic("capacity is lower than one")
+	}
+	ego := &ringBuffer{list: &list{val: make([]field, 0, capacity)}, capacity: capacity}
+	ego.Init(ego)
+	ego.Add(values...)
+	return ego
+}
+
+/*
+Evicts the first elements exceeding the capacity.
+*/
+func (ego *ringBuffer) evict() {
+	if excess := len(ego.val) - ego.capacity; excess > 0 {
+		for i := 0; i < excess; i++ {
+			ego.val[i] = nil
+		}
+		ego.val = ego.val[excess:]
+	}
+}
+
+/*
+Defined in the field interface.
+Creates a deep copy of the ring buffer.
+
+Returns:
+  - copied ring buffer.
+*/
+func (ego *ringBuffer) copy() any {
+	result := &ringBuffer{list: ego.list.copy().(*list), capacity: ego.capacity}
+	result.Init(result)
+	return result
+}
+
+func (ego *ringBuffer) Clone() List {
+	return ego.copy().(List)
+}
+
+func (ego *ringBuffer) Add(values ...any) List {
+	ego.list.Add(values...)
+	ego.evict()
+	return ego.Ego()
+}
+
+func (ego *ringBuffer) Insert(index int, value any) List {
+	ego.list.Insert(index, value)
+	if index == 0 {
+		if last := len(ego.val) - 1; last >= ego.capacity {
+			ego.val[last] = nil
+			ego.val = ego.val[:last]
+		}
+	}
+	ego.evict()
+	return ego.Ego()
+}
+
+func (ego *ringBuffer) InsertAll(index int, values ...any) List {
+	if ego.resolveIndex(index, len(ego.val)+1) > 0 {
+		return ego.list.InsertAll(index, values...)
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		ego.Ego().Insert(0, values[i])
+	}
+	return ego.Ego()
+}
@@ -0,0 +1,64 @@
+package anytype_test
+
+import (
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestRingBuffer(t *testing.T) {
+
+	t.Run("eviction", func(t *testing.T) {
+		r := anytype.NewRingBuffer(3, 1, 2)
+		r.Add(3)
+		if !r.Equals(List(1, 2, 3)) {
+			t.Fatal("ring buffer should keep elements up to its capacity")
+		}
+		r.Add(4, 5)
+		if !r.Equals(List(3, 4, 5)) {
+			t.Error("ring buffer does not evict the oldest elements")
+		}
+		r.Insert(1, 3.5)
+		if !r.Equals(List(3.5, 4, 5)) {
+			t.Error("inserting into ring buffer does not evict properly")
+		}
+		for i := 0; i < 1000; i++ {
+			r.Add(i)
+		}
+		if !r.Equals(List(997, 998, 999)) {
+			t.Error("repeated adding does not work properly")
+		}
+		if !anytype.NewRingBuffer(2, 1, 2, 3, 4).Equals(List(3, 4)) {
+			t.Error("initial elements are not evicted")
+		}
+		r = anytype.NewRingBuffer(3, 1, 2, 3)
+		if !r.Insert(0, 0).Equals(List(0, 1, 2)) {
+			t.Error("inserting at the beginning of a full ring buffer does not keep the element")
+		}
+		if !r.Unshift(-2, -1).Equals(List(-2, -1, 0)) || !r.InsertAll(-3, -4, -3).Equals(List(-4, -3, -2)) {
+			t.Error("unshifting into a full ring buffer does not keep the elements")
+		}
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		r := anytype.NewRingBuffer(2, 1, 2)
+		c := r.Clone().Add(3)
+		if !r.Equals(List(1, 2)) || !c.Equals(List(2, 3)) {
+			t.Error("cloning of ring buffer does not work properly")
+		}
+	})
+
+}
+
+func TestRingBufferPanics(t *testing.T) {
+
+	t.Run("invalidCapacity", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("zero capacity did not cause panic")
+			}
+		}()
+		anytype.NewRingBuffer(0)
+	})
+
+}
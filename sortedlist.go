@@ -0,0 +1,179 @@
+/*
+AnyType Library for Go
+Sorted implementation of the List
+*/
+
+package anytype
+
+import (
+	"math/rand"
+	"sort"
+)
+
+/*
+Sorted list, a list keeping its elements in the total ordering of values (see Compare).
+
+Implements:
+  - field,
+  - List.
+*/
+type sortedList struct {
+	*list
+}
+
+/*
+NewSortedList creates a new sorted list.
+Added elements are placed according to the total ordering of values (see Compare), equal elements keep the order of adding.
+Contains, IndexOf, ContainsDeep and IndexOfDeep use binary search.
+Elements cannot be inserted at a position and the list cannot be reordered,
+changes of nested objects and lists are not tracked.
+
+Parameters:
+  - values... - any amount of initial elements.
+
+Returns:
+  - pointer to the created sorted list.
+*/
+func NewSortedList(values ...any) List {
+	ego := &sortedList{list: &list{val: []field{}}}
+	ego.Init(ego)
+	ego.Add(values...)
+	return ego
+}
+
+/*
+Finds a range of elements equal to a given value in the ordering.
+
+Parameters:
+  - value - normalized value to search.
+
+Returns:
+  - index of the first equal element,
+  - index after the last equal element.
+*/
+func (ego *sortedList) equalRange(value any) (int, int) {
+	start := sort.Search(len(ego.val), func(i int) bool {
+		return compareValues(ego.val[i].getVal(), value) >= 0
+	})
+	end := sort.Search(len(ego.val), func(i int) bool {
+		return compareValues(ego.val[i].getVal(), value) > 0
+	})
+	return start, end
+}
+
+/*
+Defined in the field interface.
+Creates a deep copy of the sorted list.
+
+Returns:
+  - copied sorted list.
+*/
+func (ego *sortedList) copy() any {
+	result := &sortedList{list: ego.list.copy().(*list)}
+	result.Init(result)
+	return result
+}
+
+func (ego *sortedList) Clone() List {
+	return ego.copy().(List)
+}
+
+func (ego *sortedList) Add(values ...any) List {
+	for _, value := range values {
+		item := parseVal(value)
+		_, index := ego.equalRange(item.getVal())
+		ego.val = append(ego.val, nil)
+		copy(ego.val[index+1:], ego.val[index:])
+		ego.val[index] = item
+	}
+	return ego.Ego()
+}
+
+func (ego *sortedList) Insert(index int, value any) List {
+	panic("elements of a sorted list cannot be inserted at a position")
+}
+
+func (ego *sortedList) Replace(index int, value any) List {
+	item := parseVal(value)
+	ego.list.Delete(index)
+	return ego.Ego().Add(item.getVal())
+}
+
+func (ego *sortedList) Splice(start int, deleteCount int, items ...any) List {
+	if len(items) > 0 {
+		panic("elements of a sorted list cannot be inserted at a position")
+	}
+	return ego.list.Splice(start, deleteCount)
+}
+
+func (ego *sortedList) Contains(elem any) bool {
+	return ego.Ego().IndexOf(elem) >= 0
+}
+
+func (ego *sortedList) IndexOf(elem any) int {
+	switch elem.(type) {
+	case nil, bool, int, float64, string, Object, List:
+		start, end := ego.equalRange(elem)
+		for i := start; i < end; i++ {
+			if ego.val[i].getVal() == elem {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (ego *sortedList) ContainsDeep(elem any) bool {
+	return ego.Ego().IndexOfDeep(elem) >= 0
+}
+
+func (ego *sortedList) IndexOfDeep(elem any) int {
+	value := parseVal(elem)
+	start, end := ego.equalRange(value.getVal())
+	for i := start; i < end; i++ {
+		if ego.val[i].isEqual(value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ego *sortedList) Sort() List {
+	return ego.Ego()
+}
+
+func (ego *sortedList) SortFunc(less func(any, any) bool) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) SortStable(less func(any, any) bool) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) SortBy(tfs ...string) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) Reverse() List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) Swap(i int, j int) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) Move(from int, to int) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) Rotate(k int) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) Shuffle(source *rand.Rand) List {
+	panic("sorted list cannot be reordered")
+}
+
+func (ego *sortedList) Fill(value any, start int, end int) List {
+	panic("sorted list cannot be filled at positions")
+}
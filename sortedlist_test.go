@@ -0,0 +1,89 @@
+package anytype_test
+
+import (
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestSortedList(t *testing.T) {
+
+	t.Run("ordering", func(t *testing.T) {
+		s := anytype.NewSortedList(3, "b", 1.5, nil, List(1), 1, "a")
+		if !s.Equals(List(nil, 1, 1.5, 3, "a", "b", List(1))) {
+			t.Fatal("elements are not kept sorted")
+		}
+		s.Add(2).Replace(0, 10).Delete(0)
+		if !s.Equals(List(1.5, 2, 3, 10, "a", "b", List(1))) || !s.IsSorted() {
+			t.Error("changing elements does not keep the list sorted")
+		}
+		if !s.Clone().Add(0).Equals(List(0, 1.5, 2, 3, 10, "a", "b", List(1))) || s.Count() != 7 {
+			t.Error("cloning of sorted list does not work properly")
+		}
+		if !s.Sort().Equals(s) {
+			t.Error("sorting of sorted list should not change it")
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		o := Object("id", 1)
+		s := anytype.NewSortedList(5, 3, 3, "x", o, 1)
+		if s.IndexOf(3) != 1 || s.IndexOf(4) != -1 || s.IndexOf(3.0) != -1 || s.IndexOf(int8(3)) != -1 {
+			t.Error("IndexOf does not work properly")
+		}
+		if !s.Contains("x") || !s.Contains(o) || s.Contains(Object("id", 1)) {
+			t.Error("Contains does not work properly")
+		}
+		if !s.ContainsDeep(Object("id", 1)) || s.IndexOfDeep(Object("id", 1)) != 5 || s.ContainsDeep(Object("id", 2)) {
+			t.Error("deep search does not work properly")
+		}
+	})
+
+}
+
+func TestSortedListPanics(t *testing.T) {
+
+	catch := func(msg string) {
+		if r := recover(); r == nil {
+			t.Error(msg)
+		}
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		defer catch("inserting into sorted list did not cause panic")
+		anytype.NewSortedList(1, 2).Insert(0, 3)
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		defer catch("reversing sorted list did not cause panic")
+		anytype.NewSortedList(1, 2).Reverse()
+	})
+
+	t.Run("splice", func(t *testing.T) {
+		s := anytype.NewSortedList(1, 2)
+		defer func() {
+			if !s.Equals(List(1, 2)) {
+				t.Error("sorted list has been changed by a panicking Splice")
+			}
+		}()
+		defer catch("splicing elements into sorted list did not cause panic")
+		s.Splice(0, 1, 3)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		s := anytype.NewSortedList(1, 2)
+		defer func() {
+			if !s.Equals(List(1, 2)) {
+				t.Error("sorted list has been changed by a panicking Replace")
+			}
+		}()
+		defer catch("replacing by an incompatible value did not cause panic")
+		s.Replace(0, struct{}{})
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		defer catch("swapping elements of sorted list did not cause panic")
+		anytype.NewSortedList(1, 2).Swap(0, 1)
+	})
+
+}
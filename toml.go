@@ -0,0 +1,1014 @@
+/*
+AnyType Library for Go
+TOML parser and writer
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+Regular expressions of TOML values and keys.
+*/
+var (
+	tomlBareKey   = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	tomlDecimal   = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	tomlHex       = regexp.MustCompile(`^0x[0-9a-fA-F](_?[0-9a-fA-F])*$`)
+	tomlOct       = regexp.MustCompile(`^0o[0-7](_?[0-7])*$`)
+	tomlBin       = regexp.MustCompile(`^0b[01](_?[01])*$`)
+	tomlFloat     = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+	tomlSpecial   = regexp.MustCompile(`^[+-]?(inf|nan)$`)
+	tomlDate      = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	tomlTime      = regexp.MustCompile(`^[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?$`)
+	tomlDateTime  = regexp.MustCompile(`^([0-9]{4}-[0-9]{2}-[0-9]{2})[Tt ]([0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?)([Zz]|[+-][0-9]{2}:[0-9]{2})?$`)
+	tomlTimeStart = regexp.MustCompile(`^ [0-9]{2}:`)
+)
+
+/*
+Normalizes a TOML date-time literal.
+
+Parameters:
+  - raw - text of the literal.
+
+Returns:
+  - the date-time in RFC 3339 form ("T" as the delimiter, upper case "Z"),
+  - true if the text is a valid date-time, false otherwise.
+*/
+func normalizeTOMLDateTime(raw string) (string, bool) {
+	validTime := func(str string) bool {
+		_, err := time.Parse("15:04:05", str[:8])
+		return err == nil
+	}
+	switch {
+	case tomlDate.MatchString(raw):
+		_, err := time.Parse("2006-01-02", raw)
+		return raw, err == nil
+	case tomlTime.MatchString(raw):
+		return raw, validTime(raw)
+	}
+	match := tomlDateTime.FindStringSubmatch(raw)
+	if match == nil {
+		return "", false
+	}
+	if _, err := time.Parse("2006-01-02", match[1]); err != nil || !validTime(match[2]) {
+		return "", false
+	}
+	offset := strings.ToUpper(match[4])
+	if len(offset) > 1 {
+		if _, err := time.Parse("-07:00", offset); err != nil {
+			return "", false
+		}
+	}
+	return match[1] + "T" + match[2] + offset, true
+}
+
+/*
+Enum of table kinds, tracked to detect redefinitions.
+*/
+type tomlKind uint8
+
+const (
+	tomlImplicit tomlKind = iota
+	tomlExplicit
+	tomlDotted
+	tomlInline
+)
+
+/*
+Parser of TOML documents.
+*/
+type tomlParser struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+	kinds     map[Object]tomlKind
+	arrays    map[List]bool
+}
+
+/*
+Creates an error with the current line and column.
+
+Parameters:
+  - format - format of the message,
+  - args... - arguments of the message.
+
+Returns:
+  - created error.
+*/
+func (ego *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("not a valid TOML - %s on line %d, column %d", fmt.Sprintf(format, args...), ego.line, ego.pos-ego.lineStart+1)
+}
+
+/*
+Acquires a character relative to the current position.
+
+Parameters:
+  - offset - offset from the current position.
+
+Returns:
+  - the character (0 if out of the input).
+*/
+func (ego *tomlParser) peekAt(offset int) byte {
+	if ego.pos+offset >= len(ego.src) {
+		return 0
+	}
+	return ego.src[ego.pos+offset]
+}
+
+/*
+Acquires the character at the current position.
+
+Returns:
+  - the character (0 at the end of the input).
+*/
+func (ego *tomlParser) peek() byte {
+	return ego.peekAt(0)
+}
+
+/*
+Checks if the input is fully processed.
+
+Returns:
+  - true if the parser is at the end of the input, false otherwise.
+*/
+func (ego *tomlParser) eof() bool {
+	return ego.pos >= len(ego.src)
+}
+
+/*
+Moves the position forward, keeping track of lines.
+
+Parameters:
+  - n - number of bytes to skip.
+*/
+func (ego *tomlParser) advance(n int) {
+	for ; n > 0 && ego.pos < len(ego.src); n-- {
+		if ego.src[ego.pos] == '\n' {
+			ego.line++
+			ego.lineStart = ego.pos + 1
+		}
+		ego.pos++
+	}
+}
+
+/*
+Formats the character at the current position for error messages.
+
+Returns:
+  - quoted character or "end of input".
+*/
+func (ego *tomlParser) current() string {
+	if ego.eof() {
+		return "end of input"
+	}
+	if ego.peek() == '\n' {
+		return "end of line"
+	}
+	char, _ := utf8.DecodeRuneInString(ego.src[ego.pos:])
+	return "'" + string(char) + "'"
+}
+
+/*
+Skips spaces and tabs.
+*/
+func (ego *tomlParser) skipSpaces() {
+	for ego.peek() == ' ' || ego.peek() == '\t' {
+		ego.advance(1)
+	}
+}
+
+/*
+Skips a comment if present.
+
+Returns:
+  - error if the comment contains a control character.
+*/
+func (ego *tomlParser) skipComment() error {
+	if ego.peek() != '#' {
+		return nil
+	}
+	for !ego.eof() && ego.peek() != '\n' {
+		if char := ego.peek(); char < 0x20 && char != '\t' || char == 0x7f {
+			return ego.errorf("control character in a comment")
+		}
+		ego.advance(1)
+	}
+	return nil
+}
+
+/*
+Skips white space, comments and line breaks.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *tomlParser) skipBlank() error {
+	for {
+		ego.skipSpaces()
+		if err := ego.skipComment(); err != nil {
+			return err
+		}
+		if ego.peek() != '\n' {
+			return nil
+		}
+		ego.advance(1)
+	}
+}
+
+/*
+Checks the rest of the current line after an expression.
+
+Returns:
+  - error if the line contains anything else than a comment.
+*/
+func (ego *tomlParser) endLine() error {
+	ego.skipSpaces()
+	if err := ego.skipComment(); err != nil {
+		return err
+	}
+	if !ego.eof() && ego.peek() != '\n' {
+		return ego.errorf("expecting end of line, got %s", ego.current())
+	}
+	return nil
+}
+
+/*
+Reads a simple (not dotted) key.
+
+Returns:
+  - the key,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readSimpleKey() (string, error) {
+	switch ego.peek() {
+	case '"':
+		if strings.HasPrefix(ego.src[ego.pos:], `"""`) {
+			return "", ego.errorf("multi-line string cannot be a key")
+		}
+		return ego.readBasicString()
+	case '\'':
+		if strings.HasPrefix(ego.src[ego.pos:], "'''") {
+			return "", ego.errorf("multi-line string cannot be a key")
+		}
+		return ego.readLiteralString()
+	}
+	start := ego.pos
+	for char := ego.peek(); char >= 'A' && char <= 'Z' || char >= 'a' && char <= 'z' ||
+		char >= '0' && char <= '9' || char == '_' || char == '-'; char = ego.peek() {
+		ego.advance(1)
+	}
+	if start == ego.pos {
+		return "", ego.errorf("expecting a key, got %s", ego.current())
+	}
+	return ego.src[start:ego.pos], nil
+}
+
+/*
+Reads a dotted key.
+
+Returns:
+  - parts of the key,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readKey() ([]string, error) {
+	var keys []string
+	for {
+		key, err := ego.readSimpleKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		ego.skipSpaces()
+		if ego.peek() != '.' {
+			return keys, nil
+		}
+		ego.advance(1)
+		ego.skipSpaces()
+	}
+}
+
+/*
+Reads an escape sequence of a basic string.
+
+Returns:
+  - escaped character,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readEscape() (string, error) {
+	char := ego.peekAt(1)
+	switch char {
+	case 'b':
+		ego.advance(2)
+		return "\b", nil
+	case 't':
+		ego.advance(2)
+		return "\t", nil
+	case 'n':
+		ego.advance(2)
+		return "\n", nil
+	case 'f':
+		ego.advance(2)
+		return "\f", nil
+	case 'r':
+		ego.advance(2)
+		return "\r", nil
+	case '"', '\\':
+		ego.advance(2)
+		return string(char), nil
+	case 'u', 'U':
+		length := 4
+		if char == 'U' {
+			length = 8
+		}
+		if ego.pos+2+length <= len(ego.src) {
+			code, err := strconv.ParseUint(ego.src[ego.pos+2:ego.pos+2+length], 16, 32)
+			if err == nil && utf8.ValidRune(rune(code)) {
+				ego.advance(2 + length)
+				return string(rune(code)), nil
+			}
+		}
+		return "", ego.errorf("invalid unicode escape")
+	}
+	return "", ego.errorf("invalid escape sequence")
+}
+
+/*
+Checks if a character is allowed in a string.
+
+Parameters:
+  - char - character to check,
+  - multiline - true for multi-line strings (allowing line breaks).
+
+Returns:
+  - true if the character is allowed, false otherwise.
+*/
+func isTOMLStringChar(char byte, multiline bool) bool {
+	return char >= 0x20 && char != 0x7f || char == '\t' || multiline && char == '\n'
+}
+
+/*
+Reads a basic string (single-line or multi-line).
+
+Returns:
+  - content of the string,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readBasicString() (string, error) {
+	multiline := strings.HasPrefix(ego.src[ego.pos:], `"""`)
+	var result strings.Builder
+	if multiline {
+		ego.advance(3)
+		if ego.peek() == '\n' {
+			ego.advance(1)
+		}
+	} else {
+		ego.advance(1)
+	}
+	for {
+		char := ego.peek()
+		switch {
+		case ego.eof():
+			return "", ego.errorf("unterminated string")
+		case char == '"' && !multiline:
+			ego.advance(1)
+			return result.String(), nil
+		case char == '"' && strings.HasPrefix(ego.src[ego.pos:], `"""`):
+			quotes := 3
+			for quotes < 5 && ego.peekAt(quotes) == '"' {
+				quotes++
+			}
+			result.WriteString(strings.Repeat(`"`, quotes-3))
+			ego.advance(quotes)
+			return result.String(), nil
+		case char == '\\' && multiline && ego.continuation():
+		case char == '\\':
+			escaped, err := ego.readEscape()
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(escaped)
+		case !isTOMLStringChar(char, multiline):
+			return "", ego.errorf("control character in a string")
+		default:
+			result.WriteByte(char)
+			ego.advance(1)
+		}
+	}
+}
+
+/*
+Skips a line ending backslash of a multi-line basic string with all following white space.
+
+Returns:
+  - true if the backslash at the current position ends a line, false otherwise.
+*/
+func (ego *tomlParser) continuation() bool {
+	i := 1
+	for ego.peekAt(i) == ' ' || ego.peekAt(i) == '\t' {
+		i++
+	}
+	if ego.peekAt(i) != '\n' {
+		return false
+	}
+	ego.advance(i)
+	for ego.peek() == ' ' || ego.peek() == '\t' || ego.peek() == '\n' {
+		ego.advance(1)
+	}
+	return true
+}
+
+/*
+Reads a literal string (single-line or multi-line).
+
+Returns:
+  - content of the string,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readLiteralString() (string, error) {
+	multiline := strings.HasPrefix(ego.src[ego.pos:], "'''")
+	if multiline {
+		ego.advance(3)
+		if ego.peek() == '\n' {
+			ego.advance(1)
+		}
+	} else {
+		ego.advance(1)
+	}
+	start := ego.pos
+	for {
+		char := ego.peek()
+		switch {
+		case ego.eof():
+			return "", ego.errorf("unterminated string")
+		case char == '\'' && !multiline:
+			ego.advance(1)
+			return ego.src[start : ego.pos-1], nil
+		case char == '\'' && strings.HasPrefix(ego.src[ego.pos:], "'''"):
+			quotes := 3
+			for quotes < 5 && ego.peekAt(quotes) == '\'' {
+				quotes++
+			}
+			end := ego.pos + quotes - 3
+			ego.advance(quotes)
+			return ego.src[start:end], nil
+		case !isTOMLStringChar(char, multiline):
+			return "", ego.errorf("control character in a string")
+		default:
+			ego.advance(1)
+		}
+	}
+}
+
+/*
+Reads a value (string, number, boolean, date-time, array or inline table).
+
+Returns:
+  - parsed value,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readValue() (any, error) {
+	switch ego.peek() {
+	case '"':
+		return ego.readBasicString()
+	case '\'':
+		return ego.readLiteralString()
+	case '[':
+		return ego.readArray()
+	case '{':
+		return ego.readInlineTable()
+	}
+	start := ego.pos
+	for char := ego.peek(); !ego.eof() && char != ' ' && char != '\t' && char != '\n' &&
+		char != ',' && char != ']' && char != '}' && char != '#'; char = ego.peek() {
+		ego.advance(1)
+	}
+	raw := ego.src[start:ego.pos]
+	if tomlDate.MatchString(raw) && tomlTimeStart.MatchString(ego.src[ego.pos:]) {
+		ego.advance(1)
+		for char := ego.peek(); char >= '0' && char <= '9' || char != 0 && strings.IndexByte(":.+-Zz", char) >= 0; char = ego.peek() {
+			ego.advance(1)
+		}
+		raw = ego.src[start:ego.pos]
+	}
+	value, ok := parseTOMLScalar(raw)
+	if !ok {
+		ego.pos = start
+		if raw == "" {
+			return nil, ego.errorf("expecting a value, got %s", ego.current())
+		}
+		return nil, ego.errorf("invalid value '%s'", raw)
+	}
+	return value, nil
+}
+
+/*
+Parses a TOML scalar which is not a string.
+
+Parameters:
+  - raw - text of the scalar.
+
+Returns:
+  - parsed value (bool, int, float64 or date-time string),
+  - true if the text is valid, false otherwise.
+*/
+func parseTOMLScalar(raw string) (any, bool) {
+	switch {
+	case raw == "true" || raw == "false":
+		return raw == "true", true
+	case tomlSpecial.MatchString(raw):
+		switch strings.TrimLeft(raw, "+-") {
+		case "nan":
+			return math.NaN(), true
+		}
+		if raw[0] == '-' {
+			return math.Inf(-1), true
+		}
+		return math.Inf(1), true
+	case tomlDecimal.MatchString(raw):
+		integer, err := strconv.ParseInt(strings.ReplaceAll(raw, "_", ""), 10, 64)
+		return int(integer), err == nil && int64(int(integer)) == integer
+	case tomlHex.MatchString(raw), tomlOct.MatchString(raw), tomlBin.MatchString(raw):
+		integer, err := strconv.ParseInt(strings.ReplaceAll(raw, "_", ""), 0, 64)
+		return int(integer), err == nil && int64(int(integer)) == integer
+	case tomlFloat.MatchString(raw) && strings.ContainsAny(raw, ".eE"):
+		float, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64)
+		return float, err == nil
+	}
+	dateTime, ok := normalizeTOMLDateTime(raw)
+	return dateTime, ok
+}
+
+/*
+Reads an array. The position has to be at '['.
+
+Returns:
+  - parsed list,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readArray() (List, error) {
+	ego.advance(1)
+	result := NewList()
+	for {
+		if err := ego.skipBlank(); err != nil {
+			return nil, err
+		}
+		if ego.peek() == ']' {
+			ego.advance(1)
+			return result, nil
+		}
+		value, err := ego.readValue()
+		if err != nil {
+			return nil, err
+		}
+		result.Add(value)
+		if err := ego.skipBlank(); err != nil {
+			return nil, err
+		}
+		switch ego.peek() {
+		case ',':
+			ego.advance(1)
+		case ']':
+		default:
+			return nil, ego.errorf("expecting ',' or ']', got %s", ego.current())
+		}
+	}
+}
+
+/*
+Reads an inline table. The position has to be at '{'.
+
+Returns:
+  - parsed object,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readInlineTable() (Object, error) {
+	ego.advance(1)
+	result := NewObject()
+	ego.kinds[result] = tomlInline
+	ego.skipSpaces()
+	if ego.peek() == '}' {
+		ego.advance(1)
+		return result, nil
+	}
+	for {
+		ego.skipSpaces()
+		if err := ego.readKeyValue(result, tomlInline); err != nil {
+			return nil, err
+		}
+		ego.skipSpaces()
+		switch ego.peek() {
+		case ',':
+			ego.advance(1)
+		case '}':
+			ego.advance(1)
+			return result, nil
+		default:
+			return nil, ego.errorf("expecting ',' or '}', got %s", ego.current())
+		}
+	}
+}
+
+/*
+Reads a key-value pair and stores it into a table.
+
+Parameters:
+  - table - table to store the pair into,
+  - kind - kind of the tables created by dotted keys.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *tomlParser) readKeyValue(table Object, kind tomlKind) error {
+	pos, line, lineStart := ego.pos, ego.line, ego.lineStart
+	keys, err := ego.readKey()
+	if err != nil {
+		return err
+	}
+	if ego.peek() != '=' {
+		return ego.errorf("expecting '=', got %s", ego.current())
+	}
+	ego.advance(1)
+	ego.skipSpaces()
+	value, err := ego.readValue()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys[:len(keys)-1] {
+		if !table.KeyExists(key) {
+			child := NewObject()
+			ego.kinds[child] = kind
+			table.Set(key, child)
+			table = child
+			continue
+		}
+		child, ok := table.Get(key).(Object)
+		if !ok || ego.kinds[child] != kind {
+			ego.pos, ego.line, ego.lineStart = pos, line, lineStart
+			return ego.errorf("key '%s' is already defined", key)
+		}
+		table = child
+	}
+	key := keys[len(keys)-1]
+	if table.KeyExists(key) {
+		ego.pos, ego.line, ego.lineStart = pos, line, lineStart
+		return ego.errorf("key '%s' is already defined", key)
+	}
+	table.Set(key, value)
+	return nil
+}
+
+/*
+Reads a table header and creates the table.
+
+Parameters:
+  - root - root table of the document.
+
+Returns:
+  - the created table,
+  - error if any occurred.
+*/
+func (ego *tomlParser) readHeader(root Object) (Object, error) {
+	start := ego.pos
+	array := strings.HasPrefix(ego.src[ego.pos:], "[[")
+	if array {
+		ego.advance(2)
+	} else {
+		ego.advance(1)
+	}
+	ego.skipSpaces()
+	keys, err := ego.readKey()
+	if err != nil {
+		return nil, err
+	}
+	if array && !strings.HasPrefix(ego.src[ego.pos:], "]]") || !array && ego.peek() != ']' {
+		return nil, ego.errorf("expecting end of table header, got %s", ego.current())
+	}
+	end := ego.pos + 1
+	if array {
+		end++
+	}
+	ego.pos = start
+	table := root
+	for _, key := range keys[:len(keys)-1] {
+		if !table.KeyExists(key) {
+			child := NewObject()
+			ego.kinds[child] = tomlImplicit
+			table.Set(key, child)
+			table = child
+			continue
+		}
+		switch child := table.Get(key).(type) {
+		case Object:
+			if ego.kinds[child] == tomlInline {
+				return nil, ego.errorf("inline table '%s' cannot be extended", key)
+			}
+			table = child
+		case List:
+			if !ego.arrays[child] {
+				return nil, ego.errorf("key '%s' is already defined", key)
+			}
+			table = child.GetObject(child.Count() - 1)
+		default:
+			return nil, ego.errorf("key '%s' is already defined", key)
+		}
+	}
+	key := keys[len(keys)-1]
+	child := NewObject()
+	ego.kinds[child] = tomlExplicit
+	if array {
+		if !table.KeyExists(key) {
+			list := NewList()
+			ego.arrays[list] = true
+			table.Set(key, list)
+		}
+		list, ok := table.Get(key).(List)
+		if !ok || !ego.arrays[list] {
+			return nil, ego.errorf("key '%s' is already defined", key)
+		}
+		list.Add(child)
+	} else if !table.KeyExists(key) {
+		table.Set(key, child)
+	} else {
+		existing, ok := table.Get(key).(Object)
+		if !ok || ego.kinds[existing] != tomlImplicit {
+			return nil, ego.errorf("table '%s' is already defined", strings.Join(keys, "."))
+		}
+		ego.kinds[existing] = tomlExplicit
+		child = existing
+	}
+	ego.pos = end
+	return child, nil
+}
+
+/*
+Parses the whole document.
+
+Returns:
+  - root table,
+  - error if any occurred.
+*/
+func (ego *tomlParser) parse() (Object, error) {
+	root := NewObject()
+	table := root
+	for {
+		if err := ego.skipBlank(); err != nil {
+			return nil, err
+		}
+		if ego.eof() {
+			return root, nil
+		}
+		var err error
+		if ego.peek() == '[' {
+			table, err = ego.readHeader(root)
+		} else {
+			err = ego.readKeyValue(table, tomlDotted)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := ego.endLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+/*
+ParseTOML creates a new object from a TOML v1.0 document.
+Tables (including inline tables) become objects, arrays and arrays of tables become lists.
+Offset date-times, local date-times, local dates and local times become strings in their RFC 3339 form
+(e.g. "1979-05-27T07:32:00Z", "1979-05-27T07:32:00", "1979-05-27", "07:32:00"); a space delimiter is replaced by "T".
+
+Parameters:
+  - toml - TOML string to parse.
+
+Returns:
+  - created object,
+  - error if any occurred (with the line and column).
+*/
+func ParseTOML(toml string) (Object, error) {
+	if !utf8.ValidString(toml) {
+		return nil, fmt.Errorf("not an UTF-8 encoding")
+	}
+	toml = strings.ReplaceAll(strings.TrimPrefix(toml, "\ufeff"), "\r\n", "\n")
+	parser := &tomlParser{src: toml, line: 1, kinds: map[Object]tomlKind{}, arrays: map[List]bool{}}
+	return parser.parse()
+}
+
+/*
+Formats a key for TOML.
+
+Parameters:
+  - key - key to format.
+
+Returns:
+  - bare key if possible, quoted key otherwise.
+*/
+func formatTOMLKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return formatTOMLString(key)
+}
+
+/*
+Formats a string as a TOML basic string.
+
+Parameters:
+  - str - string to format.
+
+Returns:
+  - quoted string.
+*/
+func formatTOMLString(str string) string {
+	var result strings.Builder
+	result.WriteByte('"')
+	for _, char := range str {
+		switch char {
+		case '"':
+			result.WriteString(`\"`)
+		case '\\':
+			result.WriteString(`\\`)
+		case '\b':
+			result.WriteString(`\b`)
+		case '\t':
+			result.WriteString(`\t`)
+		case '\n':
+			result.WriteString(`\n`)
+		case '\f':
+			result.WriteString(`\f`)
+		case '\r':
+			result.WriteString(`\r`)
+		default:
+			if char < 0x20 || char == 0x7f {
+				result.WriteString(fmt.Sprintf(`\u%04X`, char))
+			} else {
+				result.WriteRune(char)
+			}
+		}
+	}
+	result.WriteByte('"')
+	return result.String()
+}
+
+/*
+Formats a value in TOML inline form.
+
+Parameters:
+  - value - value to format,
+  - path - key path of the value (for error messages).
+
+Returns:
+  - formatted value,
+  - error if the value cannot be represented in TOML.
+*/
+func formatTOMLValue(value any, path string) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", fmt.Errorf("TOML cannot represent null value of '%s'", path)
+	case string:
+		if normalized, ok := normalizeTOMLDateTime(typed); ok && normalized == typed {
+			return typed, nil
+		}
+		return formatTOMLString(typed), nil
+	case float64:
+		switch {
+		case math.IsNaN(typed):
+			return "nan", nil
+		case math.IsInf(typed, 1):
+			return "inf", nil
+		case math.IsInf(typed, -1):
+			return "-inf", nil
+		}
+		str := strconv.FormatFloat(typed, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".e") {
+			str += ".0"
+		}
+		return str, nil
+	case List:
+		items := make([]string, typed.Count())
+		for i := range items {
+			item, err := formatTOMLValue(typed.Get(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case Object:
+		if typed.Count() == 0 {
+			return "{}", nil
+		}
+		keys := sortedKeysOf(typed)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			item, err := formatTOMLValue(typed.Get(key), path+"."+key)
+			if err != nil {
+				return "", err
+			}
+			items[i] = formatTOMLKey(key) + " = " + item
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+/*
+Checks if a value is written as an array of tables.
+
+Parameters:
+  - value - value to check.
+
+Returns:
+  - true for non-empty lists of objects, false otherwise.
+*/
+func isTOMLTableArray(value any) bool {
+	list, ok := value.(List)
+	return ok && list.Count() > 0 && list.AllObjects()
+}
+
+/*
+Writes a table with its sub-tables.
+
+Parameters:
+  - result - builder to write to,
+  - table - table to write,
+  - path - formatted keys of the table,
+  - array - true if the table is an element of an array of tables.
+
+Returns:
+  - error if any value cannot be represented in TOML.
+*/
+func writeTOMLTable(result *strings.Builder, table Object, path []string, array bool) error {
+	keys := sortedKeysOf(table)
+	var simple, nested []string
+	for _, key := range keys {
+		value := table.Get(key)
+		if _, ok := value.(Object); ok || isTOMLTableArray(value) {
+			nested = append(nested, key)
+		} else {
+			simple = append(simple, key)
+		}
+	}
+	if len(path) > 0 && (array || len(simple) > 0 || len(nested) == 0) {
+		if result.Len() > 0 {
+			result.WriteByte('\n')
+		}
+		if array {
+			result.WriteString("[[" + strings.Join(path, ".") + "]]\n")
+		} else {
+			result.WriteString("[" + strings.Join(path, ".") + "]\n")
+		}
+	}
+	for _, key := range simple {
+		value, err := formatTOMLValue(table.Get(key), strings.Join(append(path, formatTOMLKey(key)), "."))
+		if err != nil {
+			return err
+		}
+		result.WriteString(formatTOMLKey(key) + " = " + value + "\n")
+	}
+	for _, key := range nested {
+		childPath := append(append([]string{}, path...), formatTOMLKey(key))
+		switch child := table.Get(key).(type) {
+		case Object:
+			if err := writeTOMLTable(result, child, childPath, false); err != nil {
+				return err
+			}
+		case List:
+			for i := 0; i < child.Count(); i++ {
+				if err := writeTOMLTable(result, child.GetObject(i), childPath, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ToTOML exports an object to a TOML v1.0 document.
+Nested objects are written as tables, non-empty lists of objects as arrays of tables, other lists as arrays.
+Keys are sorted alphabetically. Strings holding a valid date-time in RFC 3339 form (see ParseTOML) are written as date-times.
+
+Parameters:
+  - object - object to export.
+
+Returns:
+  - TOML string,
+  - error if the object contains a nil value, which cannot be represented in TOML.
+*/
+func ToTOML(object Object) (string, error) {
+	var result strings.Builder
+	if err := writeTOMLTable(&result, object, nil, false); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,234 @@
+package anytype_test
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestTOML(t *testing.T) {
+
+	t.Run("tables", func(t *testing.T) {
+		o, err := anytype.ParseTOML(`
+# service configuration
+title = "TOML" # inline comment
+site."google.com" = true
+
+[owner]
+name = 'Tom'
+
+[database]
+ports = [ 8000, 8001,
+  8002, ]
+temp = { cpu = 79.5, case.max = 72 }
+
+[servers.alpha]
+ip = "10.0.0.1"
+
+[servers]
+count = 1
+
+[[products]]
+name = "Hammer"
+
+[[products]]
+
+[products.dimensions]
+width = 1
+
+[fruit]
+apple.color = "red"
+apple.taste.sweet = true
+
+[fruit.apple.texture]
+smooth = true
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object(
+			"title", "TOML",
+			"site", Object("google.com", true),
+			"owner", Object("name", "Tom"),
+			"database", Object(
+				"ports", List(8000, 8001, 8002),
+				"temp", Object("cpu", 79.5, "case", Object("max", 72)),
+			),
+			"servers", Object("alpha", Object("ip", "10.0.0.1"), "count", 1),
+			"products", List(Object("name", "Hammer"), Object("dimensions", Object("width", 1))),
+			"fruit", Object("apple", Object(
+				"color", "red",
+				"taste", Object("sweet", true),
+				"texture", Object("smooth", true),
+			)),
+		)
+		if !o.Equals(expected) {
+			t.Error("parsing of tables does not work properly")
+		}
+	})
+
+	t.Run("values", func(t *testing.T) {
+		o, err := anytype.ParseTOML(`
+basic = "tab\t quote\" unicode\u00E9\U0001F600"
+multi = """
+Roses \
+   are red
+  violets"""""
+literal = 'C:\path'
+multiLiteral = '''
+first
+second'''''
+int = +1_000
+hex = 0xDE_ad
+oct = 0o755
+bin = 0b1101
+float = -3.14e2
+exponent = 5e+22
+inf = -inf
+nan = nan
+bool = false
+odt = 1979-05-27 07:32:00.999z
+ldt = 1979-05-27T07:32:00
+date = 1979-05-27
+time = 00:32:00.5
+offset = 1979-05-27T00:32:00+07:00
+nested = [[1, 2], ["a"], [{ x = 1 }], []]
+empty = {}
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !math.IsNaN(o.GetFloat("nan")) {
+			t.Error("parsing of nan does not work properly")
+		}
+		o.Unset("nan")
+		expected := Object(
+			"basic", "tab\t quote\" unicodeé😀",
+			"multi", "Roses are red\n  violets\"\"",
+			"literal", `C:\path`,
+			"multiLiteral", "first\nsecond''",
+			"int", 1000,
+			"hex", 0xdead,
+			"oct", 0755,
+			"bin", 13,
+			"float", -314.0,
+			"exponent", 5e22,
+			"inf", math.Inf(-1),
+			"bool", false,
+			"odt", "1979-05-27T07:32:00.999Z",
+			"ldt", "1979-05-27T07:32:00",
+			"date", "1979-05-27",
+			"time", "00:32:00.5",
+			"offset", "1979-05-27T00:32:00+07:00",
+			"nested", List(List(1, 2), List("a"), List(Object("x", 1)), List()),
+			"empty", Object(),
+		)
+		if !o.Equals(expected) {
+			t.Error("parsing of values does not work properly")
+		}
+		if strconv.IntSize == 64 {
+			wide := int64(0xdeadbeef)
+			o, err := anytype.ParseTOML("hex = 0xDEAD_beef")
+			if err != nil || o.GetInt("hex") != int(wide) {
+				t.Error("parsing of wide hexadecimal integers does not work properly")
+			}
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object(
+			"name", "service",
+			"quoted key", "line\nbreak\u0001",
+			"started", "2024-01-02T03:04:05Z",
+			"notDate", "2024-13-02",
+			"ratio", 2.0,
+			"inf", math.Inf(1),
+			"mixed", List(1, "a", Object("b", 2), Object()),
+			"empty", Object(),
+			"emptyList", List(),
+			"server", Object("host", "localhost", "tls", Object("enabled", true)),
+			"deep", Object("deeper", Object("value", 1)),
+			"workers", List(Object("id", 1, "tags", Object("zone", "a")), Object()),
+		)
+		exported, err := anytype.ToTOML(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(exported, "emptyList = []\ninf = inf\nmixed = [1, \"a\", { b = 2 }, {}]\nname = \"service\"\n") ||
+			!strings.Contains(exported, "\nstarted = 2024-01-02T03:04:05Z\n") || !strings.Contains(exported, "\n[deep.deeper]\nvalue = 1\n") {
+			t.Error("object is not exported properly")
+		}
+		parsed, err := anytype.ParseTOML(exported)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(o) {
+			t.Error("exported TOML does not round-trip")
+		}
+		if _, err := anytype.ToTOML(Object("a", List(1, nil))); err == nil || !strings.Contains(err.Error(), "a[1]") {
+			t.Error("exporting of nil value does not return expected error")
+		}
+		if _, err := anytype.ToTOML(Object("a", Object("b", Object("c", nil)))); err == nil {
+			t.Error("exporting of nil value does not return expected error")
+		}
+		if _, err := anytype.ToTOML(Object("a", List(Object("b", nil)))); err == nil {
+			t.Error("exporting of nil value does not return expected error")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for toml, position := range map[string]string{
+			"a = 1\na = 2":                            "line 2, column 1",
+			"[a]\nb = 1\n[a]":                         "line 3, column 1",
+			"[a.b]\n[a]\nb.c = 1":                     "line 3, column 1",
+			"[fruit]\napple.color = 1\n[fruit.apple]": "line 3, column 1",
+			"a = {x = 1}\n[a.b]":                      "line 2, column 1",
+			"a = [1]\n[[a]]":                          "line 2, column 1",
+			"a = 1\n[a.b]":                            "line 2, column 1",
+			"a = [1]\n[a.b]":                          "line 2, column 1",
+			"[[a]]\n[a]":                              "line 2, column 1",
+			"a.b = 1\na.b.c = 2":                      "line 2, column 1",
+			"a = 01":                                  "line 1, column 5",
+			"a = 1979-02-30":                          "line 1, column 5",
+			"a = 25:00:00":                            "line 1, column 5",
+			"a = 1979-05-27T00:32:00+25:00":           "line 1, column 5",
+			"a = 99999999999999999999":                "line 1, column 5",
+			"a = \"x\ny\"":                            "line 1, column 7",
+			"a = 'x":                                  "line 1, column 7",
+			"a = \"\\q\"":                             "line 1, column 6",
+			"a = \"\\uD800\"":                         "line 1, column 6",
+			"a = 1 b = 2":                             "line 1, column 7",
+			"a = [1 2]":                               "line 1, column 8",
+			"a = {x = 1,}":                            "line 1, column 12",
+			"a = {x = 1 y = 2}":                       "line 1, column 12",
+			"a =":                                     "line 1, column 4",
+			"a 1":                                     "line 1, column 3",
+			"= 1":                                     "line 1, column 1",
+			"\"\"\"a\"\"\" = 1":                       "line 1, column 1",
+			"'''a''' = 1":                             "line 1, column 1",
+			"[a":                                      "line 1, column 3",
+			"[[a]":                                    "line 1, column 4",
+			"# \x01":                                  "line 1, column 3",
+			"a = 'x\x01'":                             "line 1, column 7",
+		} {
+			_, err := anytype.ParseTOML(toml)
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", toml)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), position) {
+				t.Errorf("wrong position reported for %q: %s", toml, err)
+			}
+		}
+		if _, err := anytype.ParseTOML("\xa0"); err == nil {
+			t.Error("parser did not return expected error")
+		}
+		if o, err := anytype.ParseTOML("\ufeffa = 1\r\n"); err != nil || !o.Equals(Object("a", 1)) {
+			t.Error("parsing of a document with BOM and CRLF does not work properly")
+		}
+	})
+
+}
@@ -0,0 +1,171 @@
+/*
+AnyType Library for Go
+Recursive traversal and transformation of nested structures
+*/
+
+package anytype
+
+import (
+	"strconv"
+)
+
+/*
+WalkAction is an enum of decisions returned by visitors of Walk.
+*/
+type WalkAction uint8
+
+const (
+	WalkContinue WalkAction = iota
+	WalkSkip
+	WalkStop
+)
+
+/*
+Gives a type of an AnyType value.
+
+Parameters:
+  - val - value already converted to an AnyType value.
+
+Returns:
+  - integer constant representing the type (see type enum).
+*/
+func typeOfValue(val any) Type {
+	switch val.(type) {
+	case Object:
+		return TypeObject
+	case List:
+		return TypeList
+	case nil:
+		return TypeNil
+	case string:
+		return TypeString
+	case int:
+		return TypeInt
+	case bool:
+		return TypeBool
+	case float64:
+		return TypeFloat
+	default:
+		return TypeUndefined
+	}
+}
+
+/*
+Visits a value and (unless skipped) all its descendants.
+
+Parameters:
+  - path - tree form path of the value,
+  - value - the value,
+  - parent - object or list containing the value,
+  - function - visitor.
+
+Returns:
+  - true if the traversal has been stopped, false otherwise.
+*/
+func walkValue(path string, value any, parent any, function func(string, any, any) WalkAction) bool {
+	switch function(path, value, parent) {
+	case WalkStop:
+		return true
+	case WalkSkip:
+		return false
+	}
+	return walkChildren(path, value, function)
+}
+
+/*
+Visits all descendants of a value, keys of objects are visited in alphabetical order.
+
+Parameters:
+  - path - tree form path of the value,
+  - value - the value,
+  - function - visitor.
+
+Returns:
+  - true if the traversal has been stopped, false otherwise.
+*/
+func walkChildren(path string, value any, function func(string, any, any) WalkAction) bool {
+	switch v := value.(type) {
+	case Object:
+		for _, key := range sortedKeysOf(v) {
+			if walkValue(path+"."+key, v.Get(key), v, function) {
+				return true
+			}
+		}
+	case List:
+		for i := 0; i < v.Count(); i++ {
+			if walkValue(path+"#"+strconv.Itoa(i), v.Get(i), v, function) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+Creates a visitor calling a given function only for values of a given type.
+
+Parameters:
+  - t - type of the visited values,
+  - function - visitor of the values.
+
+Returns:
+  - the visitor.
+*/
+func walkType(t Type, function func(string, any) WalkAction) func(string, any, any) WalkAction {
+	return func(path string, value any, _ any) WalkAction {
+		if typeOfValue(value) == t {
+			return function(path, value)
+		}
+		return WalkContinue
+	}
+}
+
+/*
+Rebuilds all descendants of a value, applying a function to each of them (parents first).
+
+Parameters:
+  - path - tree form path of the value,
+  - value - the value,
+  - function - transforming function.
+
+Returns:
+  - new structure (or the value itself if it is not an object or a list).
+*/
+func transformChildren(path string, value any, function func(string, any) any) any {
+	switch v := value.(type) {
+	case Object:
+		result := NewObject()
+		v.ForEach(func(key string, val any) {
+			childPath := path + "." + key
+			result.Set(key, transformChildren(childPath, parseVal(function(childPath, val)).getVal(), function))
+		})
+		return result
+	case List:
+		result := NewList()
+		v.ForEach(func(i int, val any) {
+			childPath := path + "#" + strconv.Itoa(i)
+			result.Add(transformChildren(childPath, parseVal(function(childPath, val)).getVal(), function))
+		})
+		return result
+	}
+	return value
+}
+
+/*
+Creates a transforming function applying a given function only to values of a given type.
+
+Parameters:
+  - t - type of the transformed values,
+  - function - function transforming the values.
+
+Returns:
+  - the transforming function.
+*/
+func transformType(t Type, function func(string, any) any) func(string, any) any {
+	return func(path string, value any) any {
+		if typeOfValue(value) == t {
+			return function(path, value)
+		}
+		return value
+	}
+}
@@ -0,0 +1,419 @@
+/*
+AnyType Library for Go
+XML parser and writer
+*/
+
+package anytype
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Regular expression of XML names (restricted to the common characters).
+*/
+var xmlName = regexp.MustCompile(`^[\pL_:][\pL\pN_:.\-\x{B7}]*$`)
+
+/*
+Namespace bound to the "xml" prefix.
+*/
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+/*
+XMLOptions configures the conversion between XML and objects.
+
+Fields:
+  - AttributePrefix - prefix of the keys holding attributes, empty string means "@",
+  - TextKey - key holding the text of elements with attributes or children, empty string means "#text",
+  - ForceList - names of elements which always become lists, even if they occur only once (ParseXML),
+  - StripNamespaces - namespace prefixes are removed from the names and namespace declarations are omitted (ParseXML),
+  - Indent - string used for indentation of nested elements, empty string means no formatting (ToXML).
+*/
+type XMLOptions struct {
+	AttributePrefix string
+	TextKey         string
+	ForceList       []string
+	StripNamespaces bool
+	Indent          string
+}
+
+/*
+Acquires the prefix of the keys holding attributes.
+
+Returns:
+  - the prefix.
+*/
+func (ego XMLOptions) attributePrefix() string {
+	if ego.AttributePrefix == "" {
+		return "@"
+	}
+	return ego.AttributePrefix
+}
+
+/*
+Acquires the key holding the text.
+
+Returns:
+  - the key.
+*/
+func (ego XMLOptions) textKey() string {
+	if ego.TextKey == "" {
+		return "#text"
+	}
+	return ego.TextKey
+}
+
+/*
+Element being parsed.
+*/
+type xmlElement struct {
+	name     string
+	object   Object
+	text     strings.Builder
+	children bool
+	bindings map[string]string
+}
+
+/*
+Converter of XML tokens to objects.
+*/
+type xmlParser struct {
+	options XMLOptions
+	lists   map[string]bool
+	stack   []*xmlElement
+}
+
+/*
+Converts a name with a namespace resolved by the decoder back to the prefixed form.
+
+Parameters:
+  - name - the name.
+
+Returns:
+  - the name with the prefix used in the document (or without a prefix if namespaces are stripped).
+*/
+func (ego *xmlParser) name(name xml.Name) string {
+	if name.Space == "" || ego.options.StripNamespaces {
+		return name.Local
+	}
+	if name.Space == "xmlns" || name.Space == "xml" {
+		return name.Space + ":" + name.Local
+	}
+	if name.Space == xmlNamespace {
+		return "xml:" + name.Local
+	}
+	for i := len(ego.stack) - 1; i >= 0; i-- {
+		if prefix, ok := ego.stack[i].bindings[name.Space]; ok {
+			if prefix == "" {
+				return name.Local
+			}
+			return prefix + ":" + name.Local
+		}
+	}
+	return name.Space + ":" + name.Local
+}
+
+/*
+Opens a new element.
+
+Parameters:
+  - start - start token of the element.
+*/
+func (ego *xmlParser) start(start xml.StartElement) {
+	element := &xmlElement{object: NewObject(), bindings: make(map[string]string)}
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == "xmlns":
+			element.bindings[attr.Value] = attr.Name.Local
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			element.bindings[attr.Value] = ""
+		}
+	}
+	ego.stack = append(ego.stack, element)
+	element.name = ego.name(start.Name)
+	for _, attr := range start.Attr {
+		if ego.options.StripNamespaces && (attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		element.object.Set(ego.options.attributePrefix()+ego.name(attr.Name), attr.Value)
+	}
+}
+
+/*
+Closes the current element and stores its value to the parent.
+
+Returns:
+  - name and value of the element.
+*/
+func (ego *xmlParser) end() (string, any) {
+	element := ego.stack[len(ego.stack)-1]
+	ego.stack = ego.stack[:len(ego.stack)-1]
+	text := element.text.String()
+	var value any
+	switch {
+	case element.children:
+		if text = strings.TrimSpace(text); text != "" {
+			element.object.Set(ego.options.textKey(), text)
+		}
+		value = element.object
+	case !element.object.Empty():
+		if text != "" {
+			element.object.Set(ego.options.textKey(), text)
+		}
+		value = element.object
+	case text != "":
+		value = text
+	}
+	if len(ego.stack) > 0 {
+		parent := ego.stack[len(ego.stack)-1]
+		parent.children = true
+		switch {
+		case parent.object.TypeOf(element.name) == TypeList:
+			parent.object.GetList(element.name).Add(value)
+		case parent.object.KeyExists(element.name):
+			parent.object.Set(element.name, NewList(parent.object.Get(element.name), value))
+		case ego.lists[element.name]:
+			parent.object.Set(element.name, NewList(value))
+		default:
+			parent.object.Set(element.name, value)
+		}
+	}
+	return element.name, value
+}
+
+/*
+ParseXML creates a new object from an XML document, read as a stream.
+The result contains a single key, the name of the root element.
+Attributes become keys with a prefix ("@" by default) and text becomes a string, or a value of a special key ("#text" by default)
+if the element has attributes or children. Empty elements become nil, repeated elements are collected into lists.
+Namespace prefixes are kept as written in the document and the declarations are kept as attributes (unless stripped).
+Comments, processing instructions and directives are ignored.
+
+Parameters:
+  - reader - source of the document,
+  - options - XML options.
+
+Returns:
+  - created object,
+  - error if any occurred (with the line if possible).
+*/
+func ParseXML(reader io.Reader, options XMLOptions) (Object, error) {
+	decoder := xml.NewDecoder(reader)
+	ego := &xmlParser{options: options, lists: make(map[string]bool)}
+	for _, name := range options.ForceList {
+		ego.lists[name] = true
+	}
+	var result Object
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			if result == nil {
+				return nil, errors.New("not a valid XML - missing root element")
+			}
+			return result, nil
+		}
+		var syntaxError *xml.SyntaxError
+		if errors.As(err, &syntaxError) {
+			return nil, fmt.Errorf("not a valid XML - %s on line %d", syntaxError.Msg, syntaxError.Line)
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch typed := token.(type) {
+		case xml.StartElement:
+			if result != nil {
+				return nil, errors.New("not a valid XML - multiple root elements")
+			}
+			ego.start(typed)
+		case xml.EndElement:
+			if name, value := ego.end(); len(ego.stack) == 0 {
+				result = NewObject(name, value)
+			}
+		case xml.CharData:
+			if len(ego.stack) > 0 {
+				ego.stack[len(ego.stack)-1].text.Write(typed)
+			} else if len(strings.TrimSpace(string(typed))) > 0 {
+				return nil, errors.New("not a valid XML - text outside the root element")
+			}
+		}
+	}
+}
+
+/*
+Writer of XML documents.
+*/
+type xmlWriter struct {
+	writer  *bufio.Writer
+	options XMLOptions
+}
+
+/*
+Writes an escaped text.
+
+Parameters:
+  - text - text to write.
+*/
+func (ego *xmlWriter) writeText(text string) {
+	xml.EscapeText(ego.writer, []byte(text))
+}
+
+/*
+Converts a scalar value to a text.
+
+Parameters:
+  - value - value to convert,
+  - path - tree form path of the value (for error messages).
+
+Returns:
+  - the text,
+  - error if the value is not a scalar.
+*/
+func formatXMLValue(value any, path string) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case float64:
+		if math.IsInf(typed, 0) || math.IsNaN(typed) {
+			return strings.TrimPrefix(strconv.FormatFloat(typed, 'g', -1, 64), "+"), nil
+		}
+		return strconv.FormatFloat(typed, 'g', -1, 64), nil
+	case bool, int:
+		return fmt.Sprint(typed), nil
+	}
+	return "", fmt.Errorf("value of '%s' cannot be written as a text", path)
+}
+
+/*
+Writes an element (or several elements with the same name if the value is a list).
+
+Parameters:
+  - name - name of the element,
+  - value - value of the element,
+  - path - tree form path of the value (for error messages),
+  - depth - nesting depth of the element.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *xmlWriter) writeElement(name string, value any, path string, depth int) error {
+	if !xmlName.MatchString(name) {
+		return fmt.Errorf("'%s' is not a valid XML name", name)
+	}
+	if list, ok := value.(List); ok {
+		for i := 0; i < list.Count(); i++ {
+			item := list.Get(i)
+			if _, nested := item.(List); nested {
+				return fmt.Errorf("'%s#%d' is a nested list which cannot be written as XML", path, i)
+			}
+			if err := ego.writeElement(name, item, fmt.Sprintf("%s#%d", path, i), depth); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	indent := ""
+	if ego.options.Indent != "" {
+		indent = "\n" + strings.Repeat(ego.options.Indent, depth)
+		if depth == 0 {
+			indent = ""
+		}
+	}
+	ego.writer.WriteString(indent + "<" + name)
+	object, ok := value.(Object)
+	if !ok {
+		if value == nil {
+			ego.writer.WriteString("/>")
+			return nil
+		}
+		text, err := formatXMLValue(value, path)
+		if err != nil {
+			return err
+		}
+		ego.writer.WriteString(">")
+		ego.writeText(text)
+		ego.writer.WriteString("</" + name + ">")
+		return nil
+	}
+	prefix := ego.options.attributePrefix()
+	var children []string
+	text := ""
+	for _, key := range sortedKeysOf(object) {
+		switch {
+		case key == ego.options.textKey():
+			str, err := formatXMLValue(object.Get(key), path+"."+key)
+			if err != nil {
+				return err
+			}
+			text = str
+		case strings.HasPrefix(key, prefix):
+			attr := key[len(prefix):]
+			if !xmlName.MatchString(attr) {
+				return fmt.Errorf("'%s' is not a valid XML name", attr)
+			}
+			str, err := formatXMLValue(object.Get(key), path+"."+key)
+			if err != nil {
+				return err
+			}
+			ego.writer.WriteString(" " + attr + "=\"")
+			ego.writeText(str)
+			ego.writer.WriteString("\"")
+		default:
+			children = append(children, key)
+		}
+	}
+	if text == "" && len(children) == 0 {
+		ego.writer.WriteString("/>")
+		return nil
+	}
+	ego.writer.WriteString(">")
+	ego.writeText(text)
+	for _, key := range children {
+		if err := ego.writeElement(key, object.Get(key), path+"."+key, depth+1); err != nil {
+			return err
+		}
+	}
+	if ego.options.Indent != "" && len(children) > 0 {
+		ego.writer.WriteString("\n" + strings.Repeat(ego.options.Indent, depth))
+	}
+	ego.writer.WriteString("</" + name + ">")
+	return nil
+}
+
+/*
+ToXML writes an object as an XML document (with the XML declaration), the reverse of ParseXML.
+The object has to contain a single key, the name of the root element.
+Keys with the attribute prefix become attributes, the text key becomes text, other keys become child elements
+(in alphabetical order), lists become repeated elements and nil becomes an empty element.
+
+Parameters:
+  - object - object to write,
+  - writer - destination of the document,
+  - options - XML options.
+
+Returns:
+  - error if any occurred (including an object which cannot be represented in XML).
+*/
+func ToXML(object Object, writer io.Writer, options XMLOptions) error {
+	if object.Count() != 1 {
+		return errors.New("object has to contain exactly one root element")
+	}
+	root := object.Keys().GetString(0)
+	if _, ok := object.Get(root).(List); ok {
+		return errors.New("root element cannot be a list")
+	}
+	ego := &xmlWriter{writer: bufio.NewWriter(writer), options: options}
+	ego.writer.WriteString(xml.Header)
+	if err := ego.writeElement(root, object.Get(root), "."+root, 0); err != nil {
+		return err
+	}
+	ego.writer.WriteString("\n")
+	return ego.writer.Flush()
+}
@@ -0,0 +1,175 @@
+package anytype_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+const soapEnvelope = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- request -->
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:orders">
+  <soap:Body>
+    <order id="7" xml:lang="en">
+      <item>a &amp; b</item>
+      <item sku="x">b</item>
+      <note/>
+      <![CDATA[raw <text>]]>
+    </order>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestXML(t *testing.T) {
+
+	t.Run("parse", func(t *testing.T) {
+		o, err := anytype.ParseXML(strings.NewReader(soapEnvelope), anytype.XMLOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object("soap:Envelope", Object(
+			"@xmlns:soap", "http://schemas.xmlsoap.org/soap/envelope/",
+			"@xmlns", "urn:orders",
+			"soap:Body", Object("order", Object(
+				"@id", "7",
+				"@xml:lang", "en",
+				"item", List("a & b", Object("@sku", "x", "#text", "b")),
+				"note", nil,
+				"#text", "raw <text>",
+			)),
+		))
+		if !o.Equals(expected) {
+			t.Error("parsing of XML does not work properly")
+		}
+		o, err = anytype.ParseXML(strings.NewReader("<a:root xmlns:a='urn:a'><b:x xmlns:b='urn:a'> text </b:x><undeclared:y/></a:root>"), anytype.XMLOptions{})
+		if err != nil || !o.Equals(Object("a:root", Object("@xmlns:a", "urn:a", "b:x", Object("@xmlns:b", "urn:a", "#text", " text "), "undeclared:y", nil))) {
+			t.Error("parsing of namespace prefixes does not work properly")
+		}
+		o, err = anytype.ParseXML(strings.NewReader("<value>42</value>"), anytype.XMLOptions{})
+		if err != nil || !o.Equals(Object("value", "42")) {
+			t.Error("parsing of a text root does not work properly")
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		options := anytype.XMLOptions{
+			AttributePrefix: "-",
+			TextKey:         "_",
+			ForceList:       []string{"note", "order"},
+			StripNamespaces: true,
+		}
+		o, err := anytype.ParseXML(strings.NewReader(soapEnvelope), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object("Envelope", Object(
+			"Body", Object("order", List(Object(
+				"-id", "7",
+				"-lang", "en",
+				"item", List("a & b", Object("-sku", "x", "_", "b")),
+				"note", List(nil),
+				"_", "raw <text>",
+			))),
+		))
+		if !o.Equals(expected) {
+			t.Error("parsing of XML with options does not work properly")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object("catalog", Object(
+			"@version", 2,
+			"book", List(
+				Object("@id", "b1", "title", "Go & XML", "price", 9.5, "available", true),
+				Object("@id", "b2", "title", "<Untitled>", "tags", List("a", "b"), "note", nil),
+			),
+			"empty", Object(),
+		))
+		var buffer bytes.Buffer
+		if err := anytype.ToXML(o, &buffer, anytype.XMLOptions{Indent: "  "}); err != nil {
+			t.Fatal(err)
+		}
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<catalog version="2">
+  <book id="b1">
+    <available>true</available>
+    <price>9.5</price>
+    <title>Go &amp; XML</title>
+  </book>
+  <book id="b2">
+    <note/>
+    <tags>a</tags>
+    <tags>b</tags>
+    <title>&lt;Untitled&gt;</title>
+  </book>
+  <empty/>
+</catalog>
+`
+		if buffer.String() != expected {
+			t.Errorf("object is not exported properly:\n%s", buffer.String())
+		}
+		buffer.Reset()
+		if err := anytype.ToXML(Object("a", Object("@x", "\"quoted\"\n", "#text", "text", "b", "1")), &buffer, anytype.XMLOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<a x=\"&#34;quoted&#34;&#xA;\">text<b>1</b></a>\n" {
+			t.Errorf("object is not exported properly:\n%s", buffer.String())
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		o, err := anytype.ParseXML(strings.NewReader(soapEnvelope), anytype.XMLOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, indent := range []string{"", "\t"} {
+			var buffer bytes.Buffer
+			if err := anytype.ToXML(o, &buffer, anytype.XMLOptions{Indent: indent}); err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := anytype.ParseXML(&buffer, anytype.XMLOptions{})
+			if err != nil || !parsed.Equals(o) {
+				t.Error("exported XML does not round-trip")
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for xml, message := range map[string]string{
+			"<a>\n<b></a>":       "on line 2",
+			"<a>&unknown;</a>":   "on line 1",
+			"<a>":                "on line 1",
+			"":                   "missing root element",
+			"<!-- only -->":      "missing root element",
+			"<a/><b/>":           "multiple root elements",
+			"<a/>text":           "text outside the root element",
+			"<a>\n\n\n<b c></a>": "on line 4",
+		} {
+			_, err := anytype.ParseXML(strings.NewReader(xml), anytype.XMLOptions{})
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", xml)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), message) {
+				t.Errorf("wrong error reported for %q: %s", xml, err)
+			}
+		}
+		var buffer bytes.Buffer
+		for _, o := range []anytype.Object{
+			Object(),
+			Object("a", 1, "b", 2),
+			Object("a", List(1, 2)),
+			Object("a", Object("b", List(List()))),
+			Object("a", Object("@b", Object())),
+			Object("a", Object("#text", List())),
+			Object("a b", 1),
+			Object("a", Object("@1", "x")),
+		} {
+			if err := anytype.ToXML(o, &buffer, anytype.XMLOptions{}); err == nil {
+				t.Errorf("exporting of %s does not return expected error", o)
+			}
+		}
+	})
+
+}
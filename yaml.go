@@ -0,0 +1,1554 @@
+/*
+AnyType Library for Go
+YAML parser and writer
+*/
+
+package anytype
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*
+Regular expressions of the YAML 1.2 core schema.
+*/
+var (
+	yamlNull  = regexp.MustCompile(`^(~|null|Null|NULL)$`)
+	yamlBool  = regexp.MustCompile(`^(true|True|TRUE|false|False|FALSE)$`)
+	yamlInt   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlOct   = regexp.MustCompile(`^0o[0-7]+$`)
+	yamlHex   = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	yamlFloat = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+	yamlInf   = regexp.MustCompile(`^[-+]?\.(inf|Inf|INF)$`)
+	yamlNaN   = regexp.MustCompile(`^\.(nan|NaN|NAN)$`)
+)
+
+/*
+Maximal number of nodes created by resolving aliases in a single document.
+Protects the parser against documents expanding exponentially ("billion laughs").
+*/
+const yamlMaxAliasNodes = 1000000
+
+/*
+Resolves a plain scalar by the YAML 1.2 core schema.
+
+Parameters:
+  - raw - text of the scalar.
+
+Returns:
+  - resolved value (nil, bool, int, float64 or string).
+*/
+func resolveYAMLScalar(raw string) any {
+	switch {
+	case raw == "" || yamlNull.MatchString(raw):
+		return nil
+	case yamlBool.MatchString(raw):
+		return raw[0] == 't' || raw[0] == 'T'
+	case yamlInt.MatchString(raw):
+		if integer, err := strconv.ParseInt(raw, 10, bits.UintSize); err == nil {
+			return int(integer)
+		}
+	case yamlOct.MatchString(raw):
+		if integer, err := strconv.ParseInt(raw[2:], 8, bits.UintSize); err == nil {
+			return int(integer)
+		}
+	case yamlHex.MatchString(raw):
+		if integer, err := strconv.ParseInt(raw[2:], 16, bits.UintSize); err == nil {
+			return int(integer)
+		}
+	case yamlInf.MatchString(raw):
+		if raw[0] == '-' {
+			return math.Inf(-1)
+		}
+		return math.Inf(1)
+	case yamlNaN.MatchString(raw):
+		return math.NaN()
+	}
+	if yamlFloat.MatchString(raw) {
+		if float, err := strconv.ParseFloat(raw, 64); err == nil {
+			return float
+		}
+	}
+	return raw
+}
+
+/*
+Recursive descent parser of YAML streams.
+*/
+type yamlParser struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+	anchors   map[string]any
+	expanded  int
+}
+
+/*
+Creates an error with the current line number.
+
+Parameters:
+  - format - format of the message,
+  - args... - arguments of the message.
+
+Returns:
+  - created error.
+*/
+func (ego *yamlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("not a valid YAML - %s on line %d", fmt.Sprintf(format, args...), ego.line)
+}
+
+/*
+Acquires a character relative to the current position.
+
+Parameters:
+  - offset - offset from the current position.
+
+Returns:
+  - the character (0 if out of the input).
+*/
+func (ego *yamlParser) peekAt(offset int) byte {
+	if ego.pos+offset >= len(ego.src) {
+		return 0
+	}
+	return ego.src[ego.pos+offset]
+}
+
+/*
+Acquires the character at the current position.
+
+Returns:
+  - the character (0 at the end of the input).
+*/
+func (ego *yamlParser) peek() byte {
+	return ego.peekAt(0)
+}
+
+/*
+Checks if the input is fully processed.
+
+Returns:
+  - true if the parser is at the end of the input, false otherwise.
+*/
+func (ego *yamlParser) eof() bool {
+	return ego.pos >= len(ego.src)
+}
+
+/*
+Moves the position forward, keeping track of lines.
+
+Parameters:
+  - n - number of bytes to skip.
+*/
+func (ego *yamlParser) advance(n int) {
+	for ; n > 0 && ego.pos < len(ego.src); n-- {
+		if ego.src[ego.pos] == '\n' {
+			ego.line++
+			ego.lineStart = ego.pos + 1
+		}
+		ego.pos++
+	}
+}
+
+/*
+Acquires the column of the current position.
+
+Returns:
+  - zero-based column.
+*/
+func (ego *yamlParser) column() int {
+	return ego.pos - ego.lineStart
+}
+
+/*
+Checks if only white space precedes the current position on its line.
+
+Returns:
+  - true if the position is at the beginning of the line content, false otherwise.
+*/
+func (ego *yamlParser) atLineStart() bool {
+	return strings.Trim(ego.src[ego.lineStart:ego.pos], " \t") == ""
+}
+
+/*
+Checks if a character separates tokens (white space, line break or end of input).
+
+Parameters:
+  - char - character to check.
+
+Returns:
+  - true if the character is a separator, false otherwise.
+*/
+func isYAMLSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == 0
+}
+
+/*
+Checks if a character is a flow collection indicator.
+
+Parameters:
+  - char - character to check.
+
+Returns:
+  - true if the character is an indicator, false otherwise.
+*/
+func isYAMLFlowIndicator(char byte) bool {
+	return char == ',' || char == '[' || char == ']' || char == '{' || char == '}'
+}
+
+/*
+Skips spaces and tabs on the current line.
+*/
+func (ego *yamlParser) skipSpaces() {
+	for ego.peek() == ' ' || ego.peek() == '\t' {
+		ego.advance(1)
+	}
+}
+
+/*
+Skips white space, comments and line breaks.
+
+Returns:
+  - true if any line break was skipped, false otherwise.
+*/
+func (ego *yamlParser) skipBlank() bool {
+	crossed := false
+	for {
+		ego.skipSpaces()
+		switch ego.peek() {
+		case '#':
+			if ego.pos > ego.lineStart && !isYAMLSpace(ego.src[ego.pos-1]) {
+				return crossed
+			}
+			for !ego.eof() && ego.peek() != '\n' {
+				ego.advance(1)
+			}
+		case '\n':
+			ego.advance(1)
+			crossed = true
+		default:
+			return crossed
+		}
+	}
+}
+
+/*
+Checks if a document marker is at the current position.
+
+Parameters:
+  - marker - "---" for the start of a document, "..." for its end.
+
+Returns:
+  - true if the marker is present, false otherwise.
+*/
+func (ego *yamlParser) atMarker(marker string) bool {
+	return ego.column() == 0 && strings.HasPrefix(ego.src[ego.pos:], marker) && isYAMLSpace(ego.peekAt(3))
+}
+
+/*
+Checks if a document marker of any kind is at the current position.
+
+Returns:
+  - true if a marker is present, false otherwise.
+*/
+func (ego *yamlParser) atDocumentBoundary() bool {
+	return ego.atMarker("---") || ego.atMarker("...")
+}
+
+/*
+Checks if a block sequence entry starts at the current position.
+
+Returns:
+  - true if the position is at an entry indicator, false otherwise.
+*/
+func (ego *yamlParser) atSequenceEntry() bool {
+	return ego.peek() == '-' && isYAMLSpace(ego.peekAt(1))
+}
+
+/*
+Checks the rest of the current line (after a node) and moves to the next content.
+
+Returns:
+  - error if the line contains anything else than a comment.
+*/
+func (ego *yamlParser) endLine() error {
+	ego.skipSpaces()
+	if !ego.eof() && ego.peek() != '\n' && ego.peek() != '#' && !ego.atLineStart() {
+		return ego.errorf("unexpected '%c'", ego.peek())
+	}
+	ego.skipBlank()
+	return ego.checkIndentation()
+}
+
+/*
+Checks that the indentation of the current line does not contain tabs.
+
+Returns:
+  - error if the content at the current position is indented by a tab.
+*/
+func (ego *yamlParser) checkIndentation() error {
+	if ego.atLineStart() && strings.ContainsRune(ego.src[ego.lineStart:ego.pos], '\t') {
+		return ego.errorf("tabs are not allowed in indentation")
+	}
+	return nil
+}
+
+/*
+Reads a name of an anchor, an alias or a tag.
+
+Returns:
+  - the name.
+*/
+func (ego *yamlParser) readName() string {
+	start := ego.pos
+	if ego.peek() == '!' && ego.peekAt(1) == '<' {
+		for !ego.eof() && ego.peek() != '>' && ego.peek() != '\n' {
+			ego.advance(1)
+		}
+		ego.advance(1)
+		return ego.src[start:ego.pos]
+	}
+	for !isYAMLSpace(ego.peek()) && !isYAMLFlowIndicator(ego.peek()) {
+		ego.advance(1)
+	}
+	return ego.src[start:ego.pos]
+}
+
+/*
+Reads node properties (anchor and tag) if present.
+
+Returns:
+  - anchor name (empty if not present),
+  - tag (empty if not present),
+  - error if any occurred.
+*/
+func (ego *yamlParser) readProperties() (string, string, error) {
+	anchor, tag := "", ""
+	for ego.peek() == '&' || ego.peek() == '!' {
+		if ego.peek() == '&' {
+			if anchor != "" {
+				return "", "", ego.errorf("multiple anchors of a node")
+			}
+			ego.advance(1)
+			if anchor = ego.readName(); anchor == "" {
+				return "", "", ego.errorf("empty anchor name")
+			}
+		} else {
+			if tag != "" {
+				return "", "", ego.errorf("multiple tags of a node")
+			}
+			tag = ego.readName()
+			if strings.HasPrefix(tag, "!<tag:yaml.org,2002:") {
+				tag = "!!" + strings.TrimSuffix(strings.TrimPrefix(tag, "!<tag:yaml.org,2002:"), ">")
+			}
+		}
+		ego.skipSpaces()
+	}
+	return anchor, tag, nil
+}
+
+/*
+Resolves an alias at the current position.
+
+Returns:
+  - copy of the anchored value,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readAlias() (any, error) {
+	ego.advance(1)
+	name := ego.readName()
+	value, exists := ego.anchors[name]
+	if !exists {
+		return nil, ego.errorf("unknown anchor '%s'", name)
+	}
+	if ego.expanded += countYAMLNodes(value); ego.expanded > yamlMaxAliasNodes {
+		return nil, ego.errorf("too many nodes created by aliases")
+	}
+	switch typed := value.(type) {
+	case Object:
+		return typed.Clone(), nil
+	case List:
+		return typed.Clone(), nil
+	}
+	return value, nil
+}
+
+/*
+Counts the nodes of a value.
+
+Parameters:
+  - value - the value.
+
+Returns:
+  - number of nodes (1 for a scalar).
+*/
+func countYAMLNodes(value any) int {
+	count := 1
+	switch typed := value.(type) {
+	case Object:
+		typed.ForEachValue(func(x any) {
+			count += countYAMLNodes(x)
+		})
+	case List:
+		typed.ForEachValue(func(x any) {
+			count += countYAMLNodes(x)
+		})
+	}
+	return count
+}
+
+/*
+Applies a tag to a scalar.
+
+Parameters:
+  - tag - the tag,
+  - raw - text of the scalar,
+  - value - value resolved without the tag.
+
+Returns:
+  - tagged value,
+  - error if the scalar does not match the tag.
+*/
+func (ego *yamlParser) applyTag(tag string, raw string, value any) (any, error) {
+	switch tag {
+	case "!", "!!str":
+		return raw, nil
+	case "!!null":
+		value = resolveYAMLScalar(raw)
+		if value == nil {
+			return nil, nil
+		}
+	case "!!bool":
+		value = resolveYAMLScalar(raw)
+		if _, ok := value.(bool); ok {
+			return value, nil
+		}
+	case "!!int":
+		value = resolveYAMLScalar(raw)
+		if _, ok := value.(int); ok {
+			return value, nil
+		}
+	case "!!float":
+		value = resolveYAMLScalar(raw)
+		switch typed := value.(type) {
+		case int:
+			return float64(typed), nil
+		case float64:
+			return typed, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, ego.errorf("value '%s' does not match tag '%s'", raw, tag)
+}
+
+/*
+Reads a single-quoted or a double-quoted scalar.
+Line breaks are folded into spaces, empty lines into line breaks.
+
+Returns:
+  - content of the scalar,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readQuoted() (string, error) {
+	quote := ego.peek()
+	ego.advance(1)
+	var result []byte
+	protected := 0
+	for {
+		if ego.eof() {
+			return "", ego.errorf("unexpected end of input in a quoted scalar")
+		}
+		char := ego.peek()
+		switch {
+		case char == quote:
+			if quote == '\'' && ego.peekAt(1) == '\'' {
+				result = append(result, '\'')
+				ego.advance(2)
+				continue
+			}
+			ego.advance(1)
+			return string(result), nil
+		case char == '\n':
+			end := len(result)
+			for end > protected && (result[end-1] == ' ' || result[end-1] == '\t') {
+				end--
+			}
+			result = result[:end]
+			ego.advance(1)
+			empty := 0
+			for {
+				ego.skipSpaces()
+				if ego.peek() != '\n' {
+					break
+				}
+				empty++
+				ego.advance(1)
+			}
+			if ego.atDocumentBoundary() {
+				return "", ego.errorf("document marker in a quoted scalar")
+			}
+			if empty == 0 {
+				result = append(result, ' ')
+			}
+			for ; empty > 0; empty-- {
+				result = append(result, '\n')
+			}
+		case char == '\\' && quote == '"':
+			escaped, err := ego.readEscape()
+			if err != nil {
+				return "", err
+			}
+			result = append(result, escaped...)
+			protected = len(result)
+		default:
+			result = append(result, char)
+			ego.advance(1)
+		}
+	}
+}
+
+/*
+Reads an escape sequence of a double-quoted scalar.
+
+Returns:
+  - escaped characters,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readEscape() (string, error) {
+	char := ego.peekAt(1)
+	ego.advance(2)
+	switch char {
+	case '0':
+		return "\x00", nil
+	case 'a':
+		return "\a", nil
+	case 'b':
+		return "\b", nil
+	case 't', '\t':
+		return "\t", nil
+	case 'n':
+		return "\n", nil
+	case 'v':
+		return "\v", nil
+	case 'f':
+		return "\f", nil
+	case 'r':
+		return "\r", nil
+	case 'e':
+		return "\x1b", nil
+	case ' ', '"', '/', '\\':
+		return string(char), nil
+	case 'N':
+		return "\u0085", nil
+	case '_':
+		return "\u00a0", nil
+	case 'L':
+		return "\u2028", nil
+	case 'P':
+		return "\u2029", nil
+	case '\n':
+		ego.skipSpaces()
+		return "", nil
+	case 'x', 'u', 'U':
+		length := map[byte]int{'x': 2, 'u': 4, 'U': 8}[char]
+		if ego.pos+length > len(ego.src) {
+			return "", ego.errorf("invalid escape sequence")
+		}
+		code, err := strconv.ParseUint(ego.src[ego.pos:ego.pos+length], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", ego.errorf("invalid escape sequence")
+		}
+		ego.advance(length)
+		return string(rune(code)), nil
+	}
+	return "", ego.errorf("invalid escape sequence '\\%c'", char)
+}
+
+/*
+Reads one line of a plain scalar.
+
+Parameters:
+  - flow - true if the scalar is inside a flow collection.
+
+Returns:
+  - text of the scalar,
+  - true if the scalar is followed by a mapping value indicator, false otherwise.
+*/
+func (ego *yamlParser) readPlainLine(flow bool) (string, bool) {
+	start := ego.pos
+	key := false
+	for !ego.eof() {
+		char := ego.peek()
+		if char == '\n' || char == '#' && ego.pos > start && isYAMLSpace(ego.src[ego.pos-1]) {
+			break
+		}
+		if char == ':' && (isYAMLSpace(ego.peekAt(1)) || flow && isYAMLFlowIndicator(ego.peekAt(1))) {
+			key = true
+			break
+		}
+		if flow && isYAMLFlowIndicator(char) {
+			break
+		}
+		ego.advance(1)
+	}
+	return strings.TrimRight(ego.src[start:ego.pos], " \t"), key
+}
+
+/*
+Reads a plain scalar, including its continuation lines.
+
+Parameters:
+  - indent - indentation of the parent node (continuation lines have to be indented more),
+  - flow - true if the scalar is inside a flow collection.
+
+Returns:
+  - text of the scalar,
+  - true if the scalar is an implicit key (followed by a mapping value indicator), false otherwise,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readPlain(indent int, flow bool) (string, bool, error) {
+	text, key := ego.readPlainLine(flow)
+	if key {
+		return text, true, nil
+	}
+	for ego.peek() == '\n' {
+		pos, line, lineStart := ego.pos, ego.line, ego.lineStart
+		empty := -1
+		for ego.peek() == '\n' {
+			ego.advance(1)
+			empty++
+			ego.skipSpaces()
+		}
+		char := ego.peek()
+		if ego.eof() || char == '#' || ego.atDocumentBoundary() ||
+			!flow && ego.column() <= indent ||
+			flow && (isYAMLFlowIndicator(char) || char == ':' && isYAMLSpace(ego.peekAt(1))) {
+			ego.pos, ego.line, ego.lineStart = pos, line, lineStart
+			break
+		}
+		next, nextKey := ego.readPlainLine(flow)
+		if nextKey {
+			return "", false, ego.errorf("mapping values are not allowed in this context")
+		}
+		if empty == 0 {
+			text += " " + next
+		} else {
+			text += strings.Repeat("\n", empty) + next
+		}
+	}
+	return text, false, nil
+}
+
+/*
+Reads a literal or a folded block scalar.
+
+Parameters:
+  - indent - indentation of the parent node.
+
+Returns:
+  - content of the scalar,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readBlockScalar(indent int) (string, error) {
+	folded := ego.peek() == '>'
+	ego.advance(1)
+	chomping, explicit := byte(0), 0
+	for i := 0; i < 2; i++ {
+		char := ego.peek()
+		if (char == '-' || char == '+') && chomping == 0 {
+			chomping = char
+			ego.advance(1)
+		} else if char >= '1' && char <= '9' && explicit == 0 {
+			explicit = int(char - '0')
+			ego.advance(1)
+		}
+	}
+	ego.skipSpaces()
+	if ego.peek() == '#' {
+		for !ego.eof() && ego.peek() != '\n' {
+			ego.advance(1)
+		}
+	}
+	if !ego.eof() && ego.peek() != '\n' {
+		return "", ego.errorf("unexpected '%c' in a block scalar header", ego.peek())
+	}
+	ego.advance(1)
+	return ego.readBlockLines(indent, folded, chomping, explicit)
+}
+
+/*
+Reads the content lines of a block scalar.
+
+Parameters:
+  - indent - indentation of the parent node,
+  - folded - true for a folded scalar, false for a literal one,
+  - chomping - chomping indicator ('-', '+' or 0 for clipping),
+  - explicit - explicit indentation indicator (0 for auto-detection).
+
+Returns:
+  - content of the scalar,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readBlockLines(indent int, folded bool, chomping byte, explicit int) (string, error) {
+	contentIndent := -1
+	if explicit > 0 {
+		if indent < 0 {
+			indent = 0
+		}
+		contentIndent = indent + explicit
+	}
+	var lines []string
+	for !ego.eof() {
+		end := strings.IndexByte(ego.src[ego.pos:], '\n')
+		if end < 0 {
+			end = len(ego.src) - ego.pos
+		}
+		line := ego.src[ego.pos : ego.pos+end]
+		spaces := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.Trim(line, " \t") == "" {
+			lines = append(lines, "")
+			ego.advance(end + 1)
+			continue
+		}
+		if contentIndent < 0 {
+			if spaces <= indent {
+				break
+			}
+			contentIndent = spaces
+		}
+		if spaces < contentIndent || ego.atDocumentBoundary() {
+			break
+		}
+		lines = append(lines, line[contentIndent:])
+		ego.advance(end + 1)
+	}
+	last := len(lines) - 1
+	for last >= 0 && lines[last] == "" {
+		last--
+	}
+	trailing := len(lines) - last - 1
+	lines = lines[:last+1]
+	var result strings.Builder
+	if folded {
+		normal := false
+		empty := 0
+		for i, line := range lines {
+			if line == "" {
+				empty++
+				continue
+			}
+			more := line[0] == ' ' || line[0] == '\t'
+			switch {
+			case i == empty:
+				result.WriteString(strings.Repeat("\n", empty))
+			case normal && !more && empty == 0:
+				result.WriteByte(' ')
+			case normal && !more:
+				result.WriteString(strings.Repeat("\n", empty))
+			default:
+				result.WriteString(strings.Repeat("\n", empty+1))
+			}
+			result.WriteString(line)
+			normal = !more
+			empty = 0
+		}
+	} else {
+		result.WriteString(strings.Join(lines, "\n"))
+	}
+	switch {
+	case chomping == '+':
+		if len(lines) > 0 {
+			result.WriteByte('\n')
+		}
+		result.WriteString(strings.Repeat("\n", trailing))
+	case chomping == 0 && len(lines) > 0:
+		result.WriteByte('\n')
+	}
+	return result.String(), nil
+}
+
+/*
+Parses a node in the block context.
+
+Parameters:
+  - indent - indentation of the parent collection (content has to be indented more),
+  - inline - true if the node follows a mapping key on the same line,
+  - sequenceAtIndent - true if a block sequence can have the same indentation as the parent (mapping values).
+
+Returns:
+  - parsed value,
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseBlockNode(indent int, inline bool, sequenceAtIndent bool) (any, error) {
+	ego.skipSpaces()
+	if ego.skipBlank() {
+		inline = false
+	}
+	if err := ego.checkIndentation(); err != nil {
+		return nil, err
+	}
+	if ego.eof() || ego.atDocumentBoundary() || !inline && !ego.fits(indent, sequenceAtIndent) {
+		return nil, nil
+	}
+	col := ego.column()
+	anchor, tag, err := ego.readProperties()
+	if err != nil {
+		return nil, err
+	}
+	if ego.peek() == '#' || ego.peek() == '\n' {
+		ego.skipBlank()
+		inline = false
+		if ego.eof() || ego.atDocumentBoundary() || !ego.fits(indent, sequenceAtIndent) {
+			value, err := ego.applyTag(tag, "", nil)
+			return ego.anchor(anchor, value, err)
+		}
+		col = ego.column()
+	}
+	var value any
+	raw, scalar := "", false
+	char := ego.peek()
+	switch {
+	case char == '*':
+		value, err = ego.readAlias()
+	case ego.atSequenceEntry():
+		if inline {
+			return nil, ego.errorf("block sequence is not allowed in this context")
+		}
+		value, err = ego.parseBlockSequence(ego.column())
+	case char == '[' || char == '{':
+		value, _, err = ego.parseFlowNode()
+	case char == '|' || char == '>':
+		raw, err = ego.readBlockScalar(indent)
+		value, scalar = raw, true
+	case char == '"' || char == '\'':
+		raw, err = ego.readQuoted()
+		value, scalar = raw, true
+		if err == nil {
+			ego.skipSpaces()
+			if ego.peek() == ':' && isYAMLSpace(ego.peekAt(1)) {
+				value, err = ego.startMapping(col, raw, inline)
+				scalar = false
+			}
+		}
+	case char == '?' && isYAMLSpace(ego.peekAt(1)):
+		return nil, ego.errorf("complex mapping keys are not supported")
+	case isYAMLFlowIndicator(char) || char == '@' || char == '`' || char == ':' && isYAMLSpace(ego.peekAt(1)):
+		return nil, ego.errorf("unexpected '%c'", char)
+	default:
+		var key bool
+		raw, key, err = ego.readPlain(indent, false)
+		if err == nil && key {
+			value, err = ego.startMapping(col, raw, inline)
+		} else {
+			value, scalar = resolveYAMLScalar(raw), true
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scalar && tag != "" {
+		value, err = ego.applyTag(tag, raw, value)
+	}
+	return ego.anchor(anchor, value, err)
+}
+
+/*
+Checks if the content at the current position is indented enough to belong to a node.
+
+Parameters:
+  - indent - indentation of the parent collection,
+  - sequenceAtIndent - true if a block sequence can have the same indentation as the parent.
+
+Returns:
+  - true if the content belongs to the node, false otherwise.
+*/
+func (ego *yamlParser) fits(indent int, sequenceAtIndent bool) bool {
+	col := ego.column()
+	return col > indent || sequenceAtIndent && col == indent && ego.atSequenceEntry()
+}
+
+/*
+Registers an anchored value.
+
+Parameters:
+  - anchor - anchor name (empty if the node has no anchor),
+  - value - value of the node,
+  - err - error which occurred during the parsing of the node.
+
+Returns:
+  - the value,
+  - the error.
+*/
+func (ego *yamlParser) anchor(anchor string, value any, err error) (any, error) {
+	if err == nil && anchor != "" {
+		ego.anchors[anchor] = value
+	}
+	return value, err
+}
+
+/*
+Starts a block mapping with an already read first key.
+
+Parameters:
+  - col - indentation of the mapping,
+  - key - the first key,
+  - inline - true if the mapping would follow another key on the same line.
+
+Returns:
+  - parsed object,
+  - error if any occurred.
+*/
+func (ego *yamlParser) startMapping(col int, key string, inline bool) (Object, error) {
+	if inline {
+		return nil, ego.errorf("mapping values are not allowed in this context")
+	}
+	return ego.parseBlockMapping(col, key)
+}
+
+/*
+Parses a block mapping. The position has to be at the mapping value indicator of the first key.
+
+Parameters:
+  - col - indentation of the mapping,
+  - key - the first key.
+
+Returns:
+  - parsed object,
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseBlockMapping(col int, key string) (Object, error) {
+	result := NewObject()
+	for {
+		if result.KeyExists(key) {
+			return nil, ego.errorf("duplicate key '%s'", key)
+		}
+		ego.advance(1)
+		value, err := ego.parseBlockNode(col, true, true)
+		if err != nil {
+			return nil, err
+		}
+		result.Set(key, value)
+		if err := ego.endLine(); err != nil {
+			return nil, err
+		}
+		if ego.eof() || ego.atDocumentBoundary() || ego.column() < col {
+			return result, nil
+		}
+		if ego.column() > col {
+			return nil, ego.errorf("unexpected indentation")
+		}
+		if key, err = ego.readBlockKey(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+/*
+Reads an implicit key of a block mapping, up to the mapping value indicator.
+
+Returns:
+  - the key,
+  - error if any occurred.
+*/
+func (ego *yamlParser) readBlockKey() (string, error) {
+	var key string
+	switch ego.peek() {
+	case '"', '\'':
+		var err error
+		if key, err = ego.readQuoted(); err != nil {
+			return "", err
+		}
+		ego.skipSpaces()
+	case '?', '[', '{', '|', '>', '&', '*', '!':
+		return "", ego.errorf("unsupported mapping key")
+	default:
+		if ego.atSequenceEntry() {
+			return "", ego.errorf("expecting a mapping key, got a sequence entry")
+		}
+		key, _ = ego.readPlainLine(false)
+	}
+	if ego.peek() != ':' || !isYAMLSpace(ego.peekAt(1)) {
+		return "", ego.errorf("expecting ':' after key '%s'", key)
+	}
+	return key, nil
+}
+
+/*
+Parses a block sequence. The position has to be at the first entry indicator.
+
+Parameters:
+  - col - indentation of the sequence.
+
+Returns:
+  - parsed list,
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseBlockSequence(col int) (List, error) {
+	result := NewList()
+	for {
+		ego.advance(1)
+		value, err := ego.parseBlockNode(col, false, false)
+		if err != nil {
+			return nil, err
+		}
+		result.Add(value)
+		if err := ego.endLine(); err != nil {
+			return nil, err
+		}
+		if ego.eof() || ego.atDocumentBoundary() || ego.column() < col {
+			return result, nil
+		}
+		if ego.column() > col {
+			return nil, ego.errorf("unexpected indentation")
+		}
+		if !ego.atSequenceEntry() {
+			return result, nil
+		}
+	}
+}
+
+/*
+Parses a node in the flow context.
+
+Returns:
+  - parsed value,
+  - text of the node if it is a scalar (used for mapping keys),
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseFlowNode() (any, string, error) {
+	ego.skipBlank()
+	anchor, tag, err := ego.readProperties()
+	if err != nil {
+		return nil, "", err
+	}
+	ego.skipBlank()
+	var value any
+	raw := ""
+	char := ego.peek()
+	switch {
+	case char == '[':
+		value, err = ego.parseFlowSequence()
+	case char == '{':
+		value, err = ego.parseFlowMapping()
+	case char == '*':
+		value, err = ego.readAlias()
+		if str, ok := value.(string); ok {
+			raw = str
+		}
+	case char == '"' || char == '\'':
+		raw, err = ego.readQuoted()
+		value = raw
+	case ego.eof():
+		return nil, "", ego.errorf("unexpected end of input")
+	case isYAMLFlowIndicator(char) || char == ':' && isYAMLSpace(ego.peekAt(1)):
+		if anchor == "" && tag == "" {
+			return nil, "", ego.errorf("unexpected '%c'", char)
+		}
+	case char == '#' || char == '@' || char == '`' || char == '|' || char == '>':
+		return nil, "", ego.errorf("unexpected '%c'", char)
+	default:
+		raw, _, err = ego.readPlain(0, true)
+		value = resolveYAMLScalar(raw)
+	}
+	if err == nil && tag != "" {
+		if _, collection := value.(Object); !collection {
+			if _, collection = value.(List); !collection {
+				value, err = ego.applyTag(tag, raw, value)
+			}
+		}
+	}
+	value, err = ego.anchor(anchor, value, err)
+	return value, raw, err
+}
+
+/*
+Checks if a mapping value indicator follows in the flow context.
+
+Parameters:
+  - adjacent - true if the indicator can be followed directly by the value (after quoted keys).
+
+Returns:
+  - true if the indicator is present, false otherwise.
+*/
+func (ego *yamlParser) atFlowValue(adjacent bool) bool {
+	next := ego.peekAt(1)
+	return ego.peek() == ':' && (adjacent || isYAMLSpace(next) || isYAMLFlowIndicator(next))
+}
+
+/*
+Parses a value of a flow mapping entry. The position has to be after the key.
+
+Parameters:
+  - adjacent - true if the value indicator can be followed directly by the value.
+
+Returns:
+  - parsed value (nil if there is no value),
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseFlowValue(adjacent bool) (any, error) {
+	ego.skipBlank()
+	if !ego.atFlowValue(adjacent) {
+		return nil, nil
+	}
+	ego.advance(1)
+	ego.skipBlank()
+	if char := ego.peek(); char == ',' || char == ']' || char == '}' {
+		return nil, nil
+	}
+	value, _, err := ego.parseFlowNode()
+	return value, err
+}
+
+/*
+Converts a flow node to a mapping key.
+
+Parameters:
+  - value - parsed node,
+  - raw - text of the node.
+
+Returns:
+  - the key,
+  - error if the node is not a scalar.
+*/
+func (ego *yamlParser) flowKey(value any, raw string) (string, error) {
+	switch value.(type) {
+	case Object, List:
+		return "", ego.errorf("mapping keys have to be scalars")
+	}
+	return raw, nil
+}
+
+/*
+Parses a flow sequence. The position has to be at '['.
+
+Returns:
+  - parsed list,
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseFlowSequence() (List, error) {
+	ego.advance(1)
+	result := NewList()
+	for {
+		ego.skipBlank()
+		if ego.peek() == ']' {
+			ego.advance(1)
+			return result, nil
+		}
+		quoted := ego.peek() == '"' || ego.peek() == '\''
+		value, raw, err := ego.parseFlowNode()
+		if err != nil {
+			return nil, err
+		}
+		ego.skipBlank()
+		if ego.atFlowValue(quoted) {
+			key, err := ego.flowKey(value, raw)
+			if err != nil {
+				return nil, err
+			}
+			if value, err = ego.parseFlowValue(true); err != nil {
+				return nil, err
+			}
+			value = NewObject(key, value)
+			ego.skipBlank()
+		}
+		result.Add(value)
+		switch ego.peek() {
+		case ',':
+			ego.advance(1)
+		case ']':
+		default:
+			if ego.eof() {
+				return nil, ego.errorf("unexpected end of input")
+			}
+			return nil, ego.errorf("expecting ',' or ']', got '%c'", ego.peek())
+		}
+	}
+}
+
+/*
+Parses a flow mapping. The position has to be at '{'.
+
+Returns:
+  - parsed object,
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseFlowMapping() (Object, error) {
+	ego.advance(1)
+	result := NewObject()
+	for {
+		ego.skipBlank()
+		if ego.peek() == '}' {
+			ego.advance(1)
+			return result, nil
+		}
+		quoted := ego.peek() == '"' || ego.peek() == '\''
+		node, raw, err := ego.parseFlowNode()
+		if err != nil {
+			return nil, err
+		}
+		key, err := ego.flowKey(node, raw)
+		if err != nil {
+			return nil, err
+		}
+		if result.KeyExists(key) {
+			return nil, ego.errorf("duplicate key '%s'", key)
+		}
+		value, err := ego.parseFlowValue(quoted)
+		if err != nil {
+			return nil, err
+		}
+		result.Set(key, value)
+		ego.skipBlank()
+		switch ego.peek() {
+		case ',':
+			ego.advance(1)
+		case '}':
+		default:
+			if ego.eof() {
+				return nil, ego.errorf("unexpected end of input")
+			}
+			return nil, ego.errorf("expecting ',' or '}', got '%c'", ego.peek())
+		}
+	}
+}
+
+/*
+Parses all documents of a YAML stream.
+
+Returns:
+  - list of the documents,
+  - error if any occurred.
+*/
+func (ego *yamlParser) parseStream() (List, error) {
+	documents := NewList()
+	for {
+		ego.skipBlank()
+		directives := false
+		for ego.peek() == '%' && ego.column() == 0 {
+			for !ego.eof() && ego.peek() != '\n' {
+				ego.advance(1)
+			}
+			ego.skipBlank()
+			directives = true
+		}
+		if ego.eof() {
+			if directives {
+				return nil, ego.errorf("directives without a document")
+			}
+			return documents, nil
+		}
+		if ego.atMarker("...") {
+			ego.advance(3)
+			if err := ego.endLine(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if ego.atMarker("---") {
+			ego.advance(3)
+		} else if directives {
+			return nil, ego.errorf("expecting '---' after directives")
+		}
+		ego.anchors = map[string]any{}
+		ego.expanded = 0
+		document, err := ego.parseBlockNode(-1, false, false)
+		if err != nil {
+			return nil, err
+		}
+		documents.Add(document)
+		if err := ego.endLine(); err != nil {
+			return nil, err
+		}
+		if !ego.eof() && !ego.atDocumentBoundary() {
+			return nil, ego.errorf("unexpected '%c'", ego.peek())
+		}
+	}
+}
+
+/*
+ParseYAMLStream parses all documents of a YAML 1.2 stream.
+Plain scalars are resolved by the core schema, aliases are replaced by copies of the anchored values.
+
+Parameters:
+  - yaml - YAML string to parse.
+
+Returns:
+  - list of the documents (objects, lists or scalars),
+  - error if any occurred.
+*/
+func ParseYAMLStream(yaml string) (List, error) {
+	if !utf8.ValidString(yaml) {
+		return nil, fmt.Errorf("not an UTF-8 encoding")
+	}
+	yaml = strings.ReplaceAll(strings.TrimPrefix(yaml, "\ufeff"), "\r\n", "\n")
+	parser := &yamlParser{src: yaml, line: 1}
+	return parser.parseStream()
+}
+
+/*
+ParseYAML parses a YAML 1.2 document.
+Plain scalars are resolved by the core schema, aliases are replaced by copies of the anchored values.
+
+Parameters:
+  - yaml - YAML string to parse.
+
+Returns:
+  - root of the document (object, list or scalar, nil for an empty document),
+  - error if any occurred (including a stream with multiple documents).
+*/
+func ParseYAML(yaml string) (any, error) {
+	documents, err := ParseYAMLStream(yaml)
+	if err != nil {
+		return nil, err
+	}
+	switch documents.Count() {
+	case 0:
+		return nil, nil
+	case 1:
+		return documents.Get(0), nil
+	}
+	return nil, fmt.Errorf("not a single YAML document - found %d documents", documents.Count())
+}
+
+/*
+Checks if a string can be written as a plain scalar without changing its meaning.
+
+Parameters:
+  - str - string to check.
+
+Returns:
+  - true if the string can be written unquoted, false otherwise.
+*/
+func isYAMLPlain(str string) bool {
+	if str == "" || strings.ContainsAny(str[:1], "-?:,[]{}#&*!|>'\"%@` \t") ||
+		strings.HasSuffix(str, " ") || strings.HasSuffix(str, ":") || strings.HasPrefix(str, "...") ||
+		strings.Contains(str, ": ") || strings.Contains(str, " #") {
+		return false
+	}
+	if _, ok := resolveYAMLScalar(str).(string); !ok {
+		return false
+	}
+	for _, char := range str {
+		if !unicode.IsPrint(char) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Formats a string as a YAML scalar.
+
+Parameters:
+  - str - string to format,
+  - indent - indentation of the parent node (used by block scalars),
+  - block - true if a block scalar can be used for multi-line strings.
+
+Returns:
+  - formatted scalar.
+*/
+func formatYAMLString(str string, indent int, block bool) string {
+	if isYAMLPlain(str) {
+		return str
+	}
+	content := strings.TrimRight(str, "\n")
+	if !block || !strings.Contains(content, "\n") || content[0] == ' ' || content[0] == '\t' {
+		return strconv.Quote(str)
+	}
+	for _, char := range content {
+		if char != '\n' && !unicode.IsPrint(char) {
+			return strconv.Quote(str)
+		}
+	}
+	lines := strings.Split(content, "\n")
+	for _, line := range lines {
+		if line != "" && strings.TrimSpace(line) == "" {
+			return strconv.Quote(str)
+		}
+	}
+	var result strings.Builder
+	trailing := len(str) - len(content)
+	switch trailing {
+	case 0:
+		result.WriteString("|-")
+	case 1:
+		result.WriteString("|")
+	default:
+		result.WriteString("|+")
+	}
+	prefix := strings.Repeat(" ", indent+2)
+	for _, line := range lines {
+		result.WriteByte('\n')
+		if line != "" {
+			result.WriteString(prefix + line)
+		}
+	}
+	for ; trailing > 1; trailing-- {
+		result.WriteByte('\n')
+	}
+	return result.String()
+}
+
+/*
+Formats a scalar or an empty collection in YAML.
+
+Parameters:
+  - value - value to format,
+  - indent - indentation of the parent node.
+
+Returns:
+  - formatted value.
+*/
+func formatYAMLScalar(value any, indent int) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return formatYAMLString(typed, indent, true)
+	case float64:
+		switch {
+		case math.IsNaN(typed):
+			return ".nan"
+		case math.IsInf(typed, 1):
+			return ".inf"
+		case math.IsInf(typed, -1):
+			return "-.inf"
+		}
+		str := strconv.FormatFloat(typed, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".e") {
+			str += ".0"
+		}
+		return str
+	case Object:
+		return "{}"
+	case List:
+		return "[]"
+	}
+	return fmt.Sprint(value)
+}
+
+/*
+Writes a value of a mapping entry or a sequence entry in YAML.
+
+Parameters:
+  - result - builder to write to,
+  - value - value to write,
+  - indent - indentation of the parent collection,
+  - afterKey - true for a mapping value, false for a sequence entry.
+*/
+func writeYAMLValue(result *strings.Builder, value any, indent int, afterKey bool) {
+	separator := " "
+	if afterKey {
+		separator = "\n"
+	}
+	switch typed := value.(type) {
+	case Object:
+		if typed.Count() > 0 {
+			result.WriteString(separator)
+			writeYAMLObject(result, typed, indent+2, !afterKey)
+			return
+		}
+	case List:
+		if typed.Count() > 0 {
+			result.WriteString(separator)
+			writeYAMLList(result, typed, indent+2, !afterKey)
+			return
+		}
+	}
+	result.WriteByte(' ')
+	result.WriteString(formatYAMLScalar(value, indent))
+	result.WriteByte('\n')
+}
+
+/*
+Writes a non-empty object as a YAML block mapping. Keys are sorted alphabetically.
+
+Parameters:
+  - result - builder to write to,
+  - object - object to write,
+  - indent - indentation of the mapping,
+  - compact - true if the first entry continues the current line.
+*/
+func writeYAMLObject(result *strings.Builder, object Object, indent int, compact bool) {
+	for i, key := range sortedKeysOf(object) {
+		if i > 0 || !compact {
+			result.WriteString(strings.Repeat(" ", indent))
+		}
+		result.WriteString(formatYAMLString(key, indent, false))
+		result.WriteByte(':')
+		writeYAMLValue(result, object.Get(key), indent, true)
+	}
+}
+
+/*
+Writes a non-empty list as a YAML block sequence.
+
+Parameters:
+  - result - builder to write to,
+  - list - list to write,
+  - indent - indentation of the sequence,
+  - compact - true if the first entry continues the current line.
+*/
+func writeYAMLList(result *strings.Builder, list List, indent int, compact bool) {
+	list.ForEach(func(i int, value any) {
+		if i > 0 || !compact {
+			result.WriteString(strings.Repeat(" ", indent))
+		}
+		result.WriteByte('-')
+		writeYAMLValue(result, value, indent, false)
+	})
+}
+
+/*
+ToYAML exports a value to a YAML document in block style.
+Keys of objects are sorted alphabetically, strings are quoted only if necessary, multi-line strings are written as literal block scalars.
+
+Parameters:
+  - value - value to export (object, list or any value storable in them).
+
+Returns:
+  - YAML string.
+*/
+func ToYAML(value any) string {
+	value = parseVal(value).getVal()
+	var result strings.Builder
+	switch typed := value.(type) {
+	case Object:
+		if typed.Count() > 0 {
+			writeYAMLObject(&result, typed, 0, false)
+			return result.String()
+		}
+	case List:
+		if typed.Count() > 0 {
+			writeYAMLList(&result, typed, 0, false)
+			return result.String()
+		}
+	}
+	return formatYAMLScalar(value, 0) + "\n"
+}
+
+/*
+ToYAMLStream exports a list of documents to a YAML stream.
+
+Parameters:
+  - documents - list of the documents.
+
+Returns:
+  - YAML string with every document started by "---".
+*/
+func ToYAMLStream(documents List) string {
+	var result strings.Builder
+	documents.ForEach(func(_ int, document any) {
+		result.WriteString("---\n")
+		result.WriteString(ToYAML(document))
+	})
+	return result.String()
+}
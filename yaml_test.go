@@ -0,0 +1,299 @@
+package anytype_test
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DanielSvub/anytype"
+)
+
+func TestYAML(t *testing.T) {
+
+	t.Run("block", func(t *testing.T) {
+		parsed, err := anytype.ParseYAML(`
+# deployment
+name: web
+replicas: 3
+ratio: 0.5
+enabled: true
+missing:
+labels:
+  app: web
+  "tier name": front
+ports:
+- 80
+- 443
+containers:
+  - image: nginx
+    args:
+      - - nested
+        - list
+  -
+    image: "sidecar"
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object(
+			"name", "web",
+			"replicas", 3,
+			"ratio", 0.5,
+			"enabled", true,
+			"missing", nil,
+			"labels", Object("app", "web", "tier name", "front"),
+			"ports", List(80, 443),
+			"containers", List(
+				Object("image", "nginx", "args", List(List("nested", "list"))),
+				Object("image", "sidecar"),
+			),
+		)
+		if !expected.Equals(parsed.(anytype.Object)) {
+			t.Error("parsing of block collections does not work properly")
+		}
+	})
+
+	t.Run("flow", func(t *testing.T) {
+		parsed, err := anytype.ParseYAML(`{"json": [1, 2.5, null], plain: {a: b, c}, pairs: [k: v, x], empty: [], multi: [a
+  b, c], trailing: [1,],}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object(
+			"json", List(1, 2.5, nil),
+			"plain", Object("a", "b", "c", nil),
+			"pairs", List(Object("k", "v"), "x"),
+			"empty", List(),
+			"multi", List("a b", "c"),
+			"trailing", List(1),
+		)
+		if !expected.Equals(parsed.(anytype.Object)) {
+			t.Error("parsing of flow collections does not work properly")
+		}
+	})
+
+	t.Run("scalars", func(t *testing.T) {
+		parsed, err := anytype.ParseYAML(`
+- ~
+- Null
+- FALSE
+- 0o17
+- 0x1f
+- +12
+- 1e3
+- .5
+- -.inf
+- .NaN
+- 1_000
+- !!str 42
+- !!float 1
+- ! 1
+- !custom value
+- 'it''s'
+- "tab\tunicode\u00e9\x41 \"quoted\""
+- "folded
+  line
+
+  paragraph"
+- plain
+  continued
+- "escaped \
+  break"
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list := parsed.(anytype.List)
+		if !math.IsNaN(list.GetFloat(9)) {
+			t.Error("parsing of NaN does not work properly")
+		}
+		list.Replace(9, nil)
+		expected := List(nil, nil, false, 15, 31, 12, 1000.0, 0.5, math.Inf(-1), nil, "1_000", "42", 1.0, "1", "value",
+			"it's", "tab\tunicodeéA \"quoted\"", "folded line\nparagraph", "plain continued", "escaped break")
+		if !expected.Equals(list) {
+			t.Error("parsing of scalars does not work properly")
+		}
+	})
+
+	t.Run("blockScalars", func(t *testing.T) {
+		parsed, err := anytype.ParseYAML(`
+literal: |
+  first
+    indented
+
+  last
+strip: |-
+  text
+
+keep: |+
+  text
+
+folded: >
+  some
+  folded
+    more indented
+  text
+
+  paragraph
+explicit: |2
+    two spaces
+comment: | # header comment
+  # not a comment
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Object(
+			"literal", "first\n  indented\n\nlast\n",
+			"strip", "text",
+			"keep", "text\n\n",
+			"folded", "some folded\n  more indented\ntext\nparagraph\n",
+			"explicit", "  two spaces\n",
+			"comment", "# not a comment\n",
+		)
+		if !expected.Equals(parsed.(anytype.Object)) {
+			t.Error("parsing of block scalars does not work properly")
+		}
+	})
+
+	t.Run("anchors", func(t *testing.T) {
+		parsed, err := anytype.ParseYAML(`
+defaults: &defaults
+  timeout: 30
+name: &name service
+first: *defaults
+second: *defaults
+flow: [*name, &n 1, *n]
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := parsed.(anytype.Object)
+		if !o.GetObject("first").Equals(Object("timeout", 30)) || !o.GetList("flow").Equals(List("service", 1, 1)) {
+			t.Error("aliases do not work properly")
+		}
+		o.GetObject("first").Set("timeout", 60)
+		if o.GetObject("second").GetInt("timeout") != 30 || o.GetObject("defaults").GetInt("timeout") != 30 {
+			t.Error("aliased values are not copied")
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		documents, err := anytype.ParseYAMLStream("%YAML 1.2\n---\na: 1\n...\n---\n- 2\n--- scalar\n---\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !documents.Equals(List(Object("a", 1), List(2), "scalar", nil)) {
+			t.Error("parsing of multi-document streams does not work properly")
+		}
+		if _, err := anytype.ParseYAML("a: 1\n---\nb: 2\n"); err == nil {
+			t.Error("multiple documents accepted as a single document")
+		}
+		if empty, err := anytype.ParseYAML("# nothing\n"); err != nil || empty != nil {
+			t.Error("parsing of an empty document does not work properly")
+		}
+		if documents, err := anytype.ParseYAMLStream("\ufeffa: 1\r\nb: 2\r\n"); err != nil || !documents.Equals(List(Object("a", 1, "b", 2))) {
+			t.Error("parsing of a stream with BOM and CRLF does not work properly")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		o := Object(
+			"string", "text",
+			"quoted", "true",
+			"special", "a: b",
+			"multiline", "first\nsecond\n",
+			"stripped", "first\nsecond",
+			"kept", "first\n\n",
+			"padded", " \nx",
+			"int", 1,
+			"float", 2.0,
+			"inf", math.Inf(1),
+			"nil", nil,
+			"bool", false,
+			"empty", Object(),
+			"emptyList", List(),
+			"", "empty key",
+			"list", List(1, List(2, 3), Object("a", 1, "b", List("x")), Object(), "- dash"),
+		)
+		exported := anytype.ToYAML(o)
+		if !strings.HasPrefix(exported, "\"\": empty key\nbool: false\n") {
+			t.Error("object is not exported with sorted keys")
+		}
+		parsed, err := anytype.ParseYAML(exported)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !o.Equals(parsed.(anytype.Object)) {
+			t.Error("exported YAML does not round-trip")
+		}
+		if anytype.ToYAML(List(1, Object("a", 1))) != "- 1\n- a: 1\n" {
+			t.Error("list is not exported properly")
+		}
+		if anytype.ToYAML("multi\nline") != "|-\n  multi\n  line\n" || anytype.ToYAML(Object()) != "{}\n" {
+			t.Error("scalar is not exported properly")
+		}
+		stream := anytype.ToYAMLStream(List(Object("a", 1), "b"))
+		if stream != "---\na: 1\n---\nb\n" {
+			t.Error("stream is not exported properly")
+		}
+		if documents, err := anytype.ParseYAMLStream(stream); err != nil || !documents.Equals(List(Object("a", 1), "b")) {
+			t.Error("exported stream does not round-trip")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for yaml, line := range map[string]int{
+			"a: b: c":                1,
+			"a: 1\n  b: 2":           2,
+			"a:\n  - 1\n - 2":        3,
+			"a: 1\na: 2":             2,
+			"a: [1, 2":               1,
+			"a: {b: 1]":              1,
+			"a: *missing":            1,
+			"a: \"unterminated":      1,
+			"a: \"\\q\"":             1,
+			"a: !!int text":          1,
+			"a: 1\n- b":              2,
+			"- a\nb: 1":              2,
+			"a: \"x\" y":             1,
+			"key: - item":            1,
+			"? complex":              1,
+			"a:\n  b: 1\n  [c]: 2":   3,
+			"{[a]: b}":               1,
+			"a: |x\n  b":             1,
+			"%YAML 1.2\na: 1":        2,
+			"%YAML 1.2\n":            2,
+			"a: &x &y 1":             1,
+			"a: !!str !!str 1":       1,
+			"a: \"\n---\n\"":         2,
+			"a: [\n  b,\n  c\n  d}":  4,
+			"a: plain\n  more: text": 2,
+			"- ]":                    1,
+			"a:\n\tb: 1":             2,
+			"a:\n  b: 1\n\tc: 2":     3,
+		} {
+			_, err := anytype.ParseYAMLStream(yaml)
+			if err == nil {
+				t.Errorf("parser did not return expected error for %q", yaml)
+				continue
+			}
+			if !strings.HasSuffix(err.Error(), " on line "+strconv.Itoa(line)) {
+				t.Errorf("wrong line reported for %q: %s", yaml, err)
+			}
+		}
+		laughs := "a: &a [x, x, x, x, x, x, x, x, x]\n"
+		for i := 'b'; i <= 'j'; i++ {
+			laughs += fmt.Sprintf("%c: &%c [*%c, *%c, *%c, *%c, *%c, *%c, *%c, *%c, *%c]\n", i, i, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1)
+		}
+		if _, err := anytype.ParseYAML(laughs); err == nil || !strings.Contains(err.Error(), "aliases") {
+			t.Errorf("expansion of aliases is not limited: %v", err)
+		}
+		if _, err := anytype.ParseYAML("\xa0"); err == nil {
+			t.Error("parser did not return expected error")
+		}
+	})
+
+}